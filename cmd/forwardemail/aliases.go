@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strconv"
+	"strings"
+
+	"github.com/mattwebbio/go-forwardemail/forwardemail"
+)
+
+var aliasCommands = map[string]command{
+	"list":    listAliases,
+	"get":     getAlias,
+	"create":  createAlias,
+	"update":  updateAlias,
+	"enable":  enableAlias,
+	"disable": disableAlias,
+	"delete":  deleteAlias,
+}
+
+var aliasHeader = []string{"NAME", "RECIPIENTS", "ENABLED", "LABELS", "DESCRIPTION"}
+
+func aliasRow(alias forwardemail.Alias) []string {
+	return []string{
+		alias.Name,
+		strings.Join(alias.Recipients, ","),
+		strconv.FormatBool(alias.IsEnabled),
+		strings.Join(alias.Labels, ","),
+		alias.Description,
+	}
+}
+
+func listAliases(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("aliases list")
+	name := fs.String("name", "", "only list aliases with this name")
+	recipient := fs.String("recipient", "", "only list aliases forwarding to this recipient")
+	args, err := parseArgs(fs, args, "domain")
+	if err != nil {
+		return err
+	}
+
+	aliases, err := a.client.GetAllAliasesContext(ctx, args[0], forwardemail.ListAliasParameters{Name: *name, Recipient: *recipient})
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(aliases))
+	for i, alias := range aliases {
+		rows[i] = aliasRow(alias)
+	}
+
+	return a.print(aliases, aliasHeader, rows)
+}
+
+func getAlias(ctx context.Context, a *app, args []string) error {
+	args, err := parseArgs(a.flagSet("aliases get"), args, "domain", "alias")
+	if err != nil {
+		return err
+	}
+
+	alias, err := a.client.GetAliasContext(ctx, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	return a.print(alias, aliasHeader, [][]string{aliasRow(*alias)})
+}
+
+// aliasFlags are the flags of the create and update subcommands.
+type aliasFlags struct {
+	recipients  string
+	labels      string
+	description string
+}
+
+func newAliasFlags(fs *flag.FlagSet) *aliasFlags {
+	f := &aliasFlags{}
+	fs.StringVar(&f.recipients, "recipients", "", "comma-separated recipients: email addresses, webhook URLs or domain names")
+	fs.StringVar(&f.labels, "labels", "", "comma-separated labels")
+	fs.StringVar(&f.description, "description", "", "description of the alias, cleared on update when empty")
+
+	return f
+}
+
+// parameters only includes the flags that were given, so updates leave the others unchanged.
+func (f *aliasFlags) parameters(fs *flag.FlagSet) forwardemail.AliasParameters {
+	var parameters forwardemail.AliasParameters
+	fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "recipients":
+			recipients := splitList(f.recipients)
+			parameters.Recipients = &recipients
+		case "labels":
+			labels := splitList(f.labels)
+			parameters.Labels = &labels
+		case "description":
+			if f.description == "" {
+				parameters.Clear = append(parameters.Clear, "description")
+			}
+			parameters.Description = f.description
+		}
+	})
+
+	return parameters
+}
+
+func createAlias(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("aliases create")
+	f := newAliasFlags(fs)
+	args, err := parseArgs(fs, args, "domain", "alias")
+	if err != nil {
+		return err
+	}
+
+	alias, err := a.client.CreateAliasContext(ctx, args[0], args[1], f.parameters(fs))
+	if err != nil {
+		return err
+	}
+
+	return a.print(alias, aliasHeader, [][]string{aliasRow(*alias)})
+}
+
+func updateAlias(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("aliases update")
+	f := newAliasFlags(fs)
+	args, err := parseArgs(fs, args, "domain", "alias")
+	if err != nil {
+		return err
+	}
+
+	alias, err := a.client.UpdateAliasContext(ctx, args[0], args[1], f.parameters(fs))
+	if err != nil {
+		return err
+	}
+
+	return a.print(alias, aliasHeader, [][]string{aliasRow(*alias)})
+}
+
+func enableAlias(ctx context.Context, a *app, args []string) error {
+	args, err := parseArgs(a.flagSet("aliases enable"), args, "domain", "alias")
+	if err != nil {
+		return err
+	}
+
+	alias, err := a.client.EnableAliasContext(ctx, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	return a.print(alias, aliasHeader, [][]string{aliasRow(*alias)})
+}
+
+func disableAlias(ctx context.Context, a *app, args []string) error {
+	args, err := parseArgs(a.flagSet("aliases disable"), args, "domain", "alias")
+	if err != nil {
+		return err
+	}
+
+	alias, err := a.client.DisableAliasContext(ctx, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	return a.print(alias, aliasHeader, [][]string{aliasRow(*alias)})
+}
+
+func deleteAlias(ctx context.Context, a *app, args []string) error {
+	args, err := parseArgs(a.flagSet("aliases delete"), args, "domain", "alias")
+	if err != nil {
+		return err
+	}
+
+	if err := a.client.DeleteAliasContext(ctx, args[0], args[1]); err != nil {
+		return err
+	}
+
+	return a.printMessage("deleted alias " + args[1] + "@" + args[0])
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// config is the JSON config file, e.g. {"api_key": "...", "api_url": "https://api.forwardemail.net"}.
+type config struct {
+	ApiKey string `json:"api_key"`
+	ApiUrl string `json:"api_url"`
+}
+
+// loadConfig reads the config file and overrides it with the environment. A missing file is
+// only an error when its path was given explicitly.
+func loadConfig(path string, getenv func(string) string) (config, error) {
+	var cfg config
+
+	explicit := path != ""
+	if !explicit {
+		dir, err := os.UserConfigDir()
+		if err == nil {
+			path = filepath.Join(dir, "forwardemail", "config.json")
+		}
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				return cfg, fmt.Errorf("reading config %s: %w", path, err)
+			}
+		case explicit || !errors.Is(err, fs.ErrNotExist):
+			return cfg, fmt.Errorf("reading config: %w", err)
+		}
+	}
+
+	if key := getenv("FORWARDEMAIL_API_KEY"); key != "" {
+		cfg.ApiKey = key
+	}
+	if apiUrl := getenv("FORWARDEMAIL_API_URL"); apiUrl != "" {
+		cfg.ApiUrl = apiUrl
+	}
+
+	return cfg, nil
+}
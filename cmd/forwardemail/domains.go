@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/mattwebbio/go-forwardemail/forwardemail"
+)
+
+var domainCommands = map[string]command{
+	"list":   listDomains,
+	"get":    getDomain,
+	"create": createDomain,
+	"delete": deleteDomain,
+	"verify": verifyDomain,
+}
+
+var domainHeader = []string{"NAME", "PLAN", "MX", "TXT", "CREATED"}
+
+func domainRow(d forwardemail.Domain) []string {
+	return []string{d.Name, d.Plan, strconv.FormatBool(d.HasMxRecord), strconv.FormatBool(d.HasTxtRecord), formatTime(d.CreatedAt.Time)}
+}
+
+func listDomains(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("domains list")
+	name := fs.String("name", "", "only list domains with names containing this")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	domains, err := a.client.GetAllDomainsContext(ctx, forwardemail.ListDomainParameters{Name: *name})
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(domains))
+	for i, d := range domains {
+		rows[i] = domainRow(d)
+	}
+
+	return a.print(domains, domainHeader, rows)
+}
+
+func getDomain(ctx context.Context, a *app, args []string) error {
+	args, err := parseArgs(a.flagSet("domains get"), args, "domain")
+	if err != nil {
+		return err
+	}
+
+	domain, err := a.client.GetDomainContext(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	return a.print(domain, domainHeader, [][]string{domainRow(*domain)})
+}
+
+func createDomain(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("domains create")
+	plan := fs.String("plan", "", "plan of the domain: free, enhanced_protection or team")
+	catchAll := fs.String("catch-all", "", "comma-separated recipients of the catch-all alias")
+	args, err := parseArgs(fs, args, "domain")
+	if err != nil {
+		return err
+	}
+
+	var parameters forwardemail.DomainParameters
+	if *plan != "" {
+		parameters.Plan = plan
+	}
+	parameters.CatchAllRecipients = splitList(*catchAll)
+
+	domain, err := a.client.CreateDomainContext(ctx, args[0], parameters)
+	if err != nil {
+		return err
+	}
+
+	return a.print(domain, domainHeader, [][]string{domainRow(*domain)})
+}
+
+func deleteDomain(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("domains delete")
+	force := fs.Bool("force", false, "delete the domain even if it has many aliases")
+	maxAliases := fs.Int("max-aliases", 10, "refuse to delete a domain with more aliases, unless forced")
+	args, err := parseArgs(fs, args, "domain")
+	if err != nil {
+		return err
+	}
+
+	guards := forwardemail.DeleteGuards{MaxAliases: *maxAliases, Force: *force}
+	if err := a.client.SafeDeleteDomainContext(ctx, args[0], guards); err != nil {
+		return err
+	}
+
+	return a.printMessage("deleted domain " + args[0])
+}
+
+func verifyDomain(ctx context.Context, a *app, args []string) error {
+	args, err := parseArgs(a.flagSet("domains verify"), args, "domain")
+	if err != nil {
+		return err
+	}
+
+	message, err := a.client.VerifyDomainRecordsContext(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	return a.printMessage(message)
+}
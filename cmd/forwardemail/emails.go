@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/mattwebbio/go-forwardemail/forwardemail"
+)
+
+var emailCommands = map[string]command{
+	"list":  listEmails,
+	"get":   getEmail,
+	"send":  sendEmail,
+	"limit": getEmailLimit,
+}
+
+var emailHeader = []string{"ID", "STATUS", "FROM", "TO", "SUBJECT", "DATE"}
+
+func emailRow(email forwardemail.Email) []string {
+	return []string{
+		email.Id,
+		email.Status,
+		email.Envelope.From,
+		strings.Join(email.Envelope.To, ","),
+		email.Subject,
+		formatTime(email.Date.Time),
+	}
+}
+
+func listEmails(ctx context.Context, a *app, args []string) error {
+	if _, err := parseArgs(a.flagSet("emails list"), args); err != nil {
+		return err
+	}
+
+	emails, err := a.client.GetEmailsContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(emails))
+	for i, email := range emails {
+		rows[i] = emailRow(email)
+	}
+
+	return a.print(emails, emailHeader, rows)
+}
+
+func getEmail(ctx context.Context, a *app, args []string) error {
+	args, err := parseArgs(a.flagSet("emails get"), args, "id")
+	if err != nil {
+		return err
+	}
+
+	email, err := a.client.GetEmailContext(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	return a.print(email, emailHeader, [][]string{emailRow(*email)})
+}
+
+func sendEmail(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("emails send")
+	from := fs.String("from", "", "sender address")
+	to := fs.String("to", "", "comma-separated recipient addresses")
+	subject := fs.String("subject", "", "subject of the email")
+	text := fs.String("text", "", "plain text body")
+	html := fs.String("html", "", "HTML body")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	email, err := a.client.CreateEmailContext(ctx, forwardemail.EmailParameters{
+		From:    *from,
+		To:      splitList(*to),
+		Subject: *subject,
+		Text:    *text,
+		Html:    *html,
+	})
+	if err != nil {
+		return err
+	}
+
+	return a.print(email, emailHeader, [][]string{emailRow(*email)})
+}
+
+func getEmailLimit(ctx context.Context, a *app, args []string) error {
+	if _, err := parseArgs(a.flagSet("emails limit"), args); err != nil {
+		return err
+	}
+
+	limit, err := a.client.GetEmailLimitContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.print(limit, []string{"COUNT", "LIMIT"}, [][]string{{strconv.Itoa(limit.Count), strconv.Itoa(limit.Limit)}})
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"strconv"
+
+	"github.com/mattwebbio/go-forwardemail/forwardemail"
+)
+
+var logCommands = map[string]command{
+	"list":     listLogs,
+	"download": downloadLogs,
+}
+
+func logFilterFlags(a *app, name string) (*flag.FlagSet, *forwardemail.LogFilters) {
+	fs := a.flagSet(name)
+	filters := &forwardemail.LogFilters{}
+	fs.StringVar(&filters.Search, "search", "", "only include logs matching this search")
+	fs.StringVar(&filters.BounceCategory, "bounce-category", "", "only include logs with this bounce category")
+	fs.IntVar(&filters.ResponseCode, "response-code", 0, "only include logs with this SMTP response code")
+
+	return fs, filters
+}
+
+func listLogs(ctx context.Context, a *app, args []string) error {
+	fs, filters := logFilterFlags(a, "logs list")
+	args, err := parseArgs(fs, args, "domain")
+	if err != nil {
+		return err
+	}
+
+	logs, err := a.client.GetLogsContext(ctx, args[0], *filters)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(logs))
+	for i, log := range logs {
+		rows[i] = []string{formatTime(log.CreatedAt.Time), strconv.Itoa(log.ResponseCode), log.BounceCategory, log.Message}
+	}
+
+	return a.print(logs, []string{"CREATED", "CODE", "BOUNCE", "MESSAGE"}, rows)
+}
+
+// downloadLogs writes the CSV export of the logs as is, whatever the output format.
+func downloadLogs(ctx context.Context, a *app, args []string) error {
+	fs, filters := logFilterFlags(a, "logs download")
+	args, err := parseArgs(fs, args, "domain")
+	if err != nil {
+		return err
+	}
+
+	body, err := a.client.DownloadLogsContext(ctx, args[0], *filters)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	_, err = io.Copy(a.out, body)
+
+	return err
+}
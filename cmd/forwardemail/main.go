@@ -0,0 +1,169 @@
+// Command forwardemail is a command-line client for the Forward Email API.
+//
+// Usage:
+//
+//	forwardemail [-api-key key] [-api-url url] [-config file] [-o table|json] <command> <subcommand> [flags] [args]
+//
+// The commands are domains, aliases, emails and logs; run a command without a subcommand to
+// list its subcommands. The API key is read from the -api-key flag, then the
+// FORWARDEMAIL_API_KEY environment variable, then the config file.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mattwebbio/go-forwardemail/forwardemail"
+)
+
+// command runs a subcommand of a command group with the remaining arguments.
+type command func(ctx context.Context, app *app, args []string) error
+
+var commands = map[string]map[string]command{
+	"domains": domainCommands,
+	"aliases": aliasCommands,
+	"emails":  emailCommands,
+	"logs":    logCommands,
+}
+
+// app is the state shared by every command.
+type app struct {
+	client *forwardemail.Client
+	out    io.Writer
+	errOut io.Writer
+	format string
+}
+
+// errUsage is returned for invalid invocations; the usage has already been printed.
+var errUsage = errors.New("usage")
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr, os.Getenv))
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, getenv func(string) string) int {
+	fs := flag.NewFlagSet("forwardemail", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	apiKey := fs.String("api-key", "", "API key, defaults to $FORWARDEMAIL_API_KEY or the config file")
+	apiUrl := fs.String("api-url", "", "API URL, defaults to $FORWARDEMAIL_API_URL, the config file or the public API")
+	configPath := fs.String("config", "", "path of the JSON config file, defaults to forwardemail/config.json in the user config directory")
+	format := fs.String("o", "table", "output format, table or json")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: forwardemail [flags] <%s> <subcommand> [flags] [args]\n", strings.Join(sortedKeys(commands), "|"))
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *format != "table" && *format != "json" {
+		fmt.Fprintf(stderr, "unknown output format %q\n", *format)
+		return 2
+	}
+
+	if fs.NArg() < 1 {
+		fs.Usage()
+		return 2
+	}
+	group, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n", fs.Arg(0))
+		fs.Usage()
+		return 2
+	}
+	if fs.NArg() < 2 || group[fs.Arg(1)] == nil {
+		fmt.Fprintf(stderr, "usage: forwardemail %s <%s>\n", fs.Arg(0), strings.Join(sortedKeys(group), "|"))
+		return 2
+	}
+
+	cfg, err := loadConfig(*configPath, getenv)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if *apiKey != "" {
+		cfg.ApiKey = *apiKey
+	}
+	if *apiUrl != "" {
+		cfg.ApiUrl = *apiUrl
+	}
+	if cfg.ApiKey == "" {
+		fmt.Fprintln(stderr, "no API key: use -api-key, FORWARDEMAIL_API_KEY or the config file")
+		return 1
+	}
+
+	a := &app{
+		client: forwardemail.NewClient(forwardemail.ClientOptions{ApiKey: cfg.ApiKey, ApiUrl: cfg.ApiUrl}),
+		out:    stdout,
+		errOut: stderr,
+		format: *format,
+	}
+
+	if err := group[fs.Arg(1)](ctx, a, fs.Args()[2:]); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintln(stderr, err)
+		}
+		return 1
+	}
+
+	return 0
+}
+
+// parseArgs parses the flags of a subcommand and checks it got exactly the named arguments.
+func parseArgs(fs *flag.FlagSet, args []string, names ...string) ([]string, error) {
+	fs.Usage = func() {
+		usage := "usage: forwardemail " + fs.Name()
+		for _, name := range names {
+			usage += " <" + name + ">"
+		}
+		fmt.Fprintln(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, errUsage
+	}
+	if fs.NArg() != len(names) {
+		fs.Usage()
+		return nil, errUsage
+	}
+
+	return fs.Args(), nil
+}
+
+func (a *app) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.errOut)
+
+	return fs
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// splitList splits a comma-separated flag value, returning nil for an empty value.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	items := strings.Split(s, ",")
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+
+	return items
+}
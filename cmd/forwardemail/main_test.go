@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_run(t *testing.T) {
+	var calls []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, r.Method+" "+r.URL.RequestURI()+" "+string(body))
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/v1/domains":
+			fmt.Fprint(w, `[{"name": "stark.com", "plan": "free", "has_mx_record": true}]`)
+		case r.Method == "GET" && r.URL.Path == "/v1/domains/stark.com/aliases":
+			fmt.Fprint(w, `[{"name": "tony", "recipients": ["tony@stark.com"], "is_enabled": true}]`)
+		case r.Method == "POST" && r.URL.Path == "/v1/domains/stark.com/aliases":
+			fmt.Fprint(w, `{"name": "pepper", "recipients": ["pepper@stark.com"], "labels": ["ops"], "is_enabled": true}`)
+		case r.Method == "DELETE":
+			fmt.Fprint(w, `{}`)
+		case r.URL.Path == "/v1/domains/stark.com/aliases/tony":
+			fmt.Fprint(w, `{"name": "tony", "recipients": ["tony@stark.com"], "description": "main email", "is_enabled": true}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Not Found"}`)
+		}
+	}))
+	defer svr.Close()
+
+	tests := []struct {
+		name      string
+		args      []string
+		env       map[string]string
+		wantCode  int
+		wantOut   string
+		wantCalls []string
+	}{
+		{
+			name:      "domains list",
+			args:      []string{"domains", "list"},
+			wantOut:   "NAME       PLAN  MX    TXT    CREATED\nstark.com  free  true  false  \n",
+			wantCalls: []string{"GET /v1/domains?page=1 "},
+		},
+		{
+			name:      "domains list by name",
+			args:      []string{"-o", "json", "domains", "list", "-name", "stark"},
+			wantCalls: []string{"GET /v1/domains?name=stark&page=1 "},
+		},
+		{
+			name:      "aliases list as json",
+			args:      []string{"-o", "json", "aliases", "list", "-recipient", "tony@stark.com", "stark.com"},
+			wantCalls: []string{"GET /v1/domains/stark.com/aliases?page=1&recipient=tony%40stark.com "},
+		},
+		{
+			name:      "aliases create",
+			args:      []string{"aliases", "create", "-recipients", "pepper@stark.com", "-labels", "ops", "stark.com", "pepper"},
+			wantOut:   "NAME    RECIPIENTS        ENABLED  LABELS  DESCRIPTION\npepper  pepper@stark.com  true     ops     \n",
+			wantCalls: []string{`POST /v1/domains/stark.com/aliases {"name":"pepper","recipients":["pepper@stark.com"],"labels":["ops"]}`},
+		},
+		{
+			name:      "aliases update clearing the description",
+			args:      []string{"-o", "json", "aliases", "update", "-description", "", "stark.com", "tony"},
+			wantCalls: []string{`PUT /v1/domains/stark.com/aliases/tony {"description":null}`},
+		},
+		{
+			name:      "aliases delete",
+			args:      []string{"aliases", "delete", "stark.com", "tony"},
+			wantOut:   "deleted alias tony@stark.com\n",
+			wantCalls: []string{"DELETE /v1/domains/stark.com/aliases/tony "},
+		},
+		{
+			name:     "invalid alias is rejected before sending",
+			args:     []string{"aliases", "create", "-recipients", "not a recipient", "stark.com", "pepper"},
+			wantCode: 1,
+		},
+		{
+			name:      "api errors",
+			args:      []string{"domains", "get", "wayne.com"},
+			wantCode:  1,
+			wantCalls: []string{"GET /v1/domains/wayne.com "},
+		},
+		{
+			name:     "missing argument",
+			args:     []string{"aliases", "get", "stark.com"},
+			wantCode: 1,
+		},
+		{
+			name:     "unknown command",
+			args:     []string{"mailboxes", "list"},
+			wantCode: 2,
+		},
+		{
+			name:     "no API key",
+			args:     []string{"domains", "list"},
+			env:      map[string]string{"FORWARDEMAIL_API_KEY": ""},
+			wantCode: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			env := map[string]string{"FORWARDEMAIL_API_KEY": "test_key", "FORWARDEMAIL_API_URL": svr.URL}
+			for k, v := range tt.env {
+				env[k] = v
+			}
+
+			var stdout, stderr bytes.Buffer
+			// An empty config file keeps the user's own config out of the test.
+			configPath := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(configPath, []byte(`{}`), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			args := append([]string{"-config", configPath}, tt.args...)
+
+			code := run(context.Background(), args, &stdout, &stderr, func(k string) string { return env[k] })
+
+			if code != tt.wantCode {
+				t.Errorf("exit code %d, want %d, stderr: %s", code, tt.wantCode, stderr.String())
+			}
+			if tt.wantOut != "" {
+				if diff := cmp.Diff(tt.wantOut, stdout.String()); diff != "" {
+					t.Errorf("values are not the same %s", diff)
+				}
+			}
+			if diff := cmp.Diff(tt.wantCalls, calls); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func Test_loadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"api_key": "file_key", "api_url": "https://example.com"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadConfig(path, func(k string) string {
+		if k == "FORWARDEMAIL_API_KEY" {
+			return "env_key"
+		}
+
+		return ""
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(config{ApiKey: "env_key", ApiUrl: "https://example.com"}, cfg); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+
+	if _, err := loadConfig(filepath.Join(t.TempDir(), "missing.json"), func(string) string { return "" }); err == nil {
+		t.Error("expected an error for a missing explicit config file")
+	}
+}
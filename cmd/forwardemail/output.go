@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// print writes v as indented JSON, or the header and rows as an aligned table.
+func (a *app) print(v any, header []string, rows [][]string) error {
+	if a.format == "json" {
+		enc := json.NewEncoder(a.out)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(v)
+	}
+
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	return w.Flush()
+}
+
+// printMessage writes the message of commands without a result, such as deletions.
+func (a *app) printMessage(message string) error {
+	if a.format == "json" {
+		return a.print(map[string]string{"message": message}, nil, nil)
+	}
+
+	_, err := fmt.Fprintln(a.out, message)
+
+	return err
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -1,8 +1,8 @@
 package forwardemail
 
 import (
-	"encoding/json"
-	"time"
+	"context"
+	"net/url"
 )
 
 type Account struct {
@@ -15,17 +15,88 @@ type Account struct {
 	Id             string    `json:"id"`
 	Object         string    `json:"object"`
 	Locale         string    `json:"locale"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	CreatedAt      Timestamp `json:"created_at"`
+	UpdatedAt      Timestamp `json:"updated_at"`
 	AddressHtml    string    `json:"address_html"`
+	GivenName      string    `json:"given_name"`
+	FamilyName     string    `json:"family_name"`
+	AvatarUrl      string    `json:"avatar_url"`
 }
 
-func (c *Client) GetAccount() (*Account, error) {
-	req, err := c.newRequest("GET", "/v1/account")
+type AccountParameters struct {
+	Email      *string `form:"email"`
+	GivenName  *string `form:"given_name"`
+	FamilyName *string `form:"family_name"`
+	AvatarUrl  *string `form:"avatar_url"`
+}
+
+func (c *Client) GetAccount(opts ...RequestOption) (*Account, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetAccountContext(ctx)
+}
+
+func (c *Client) GetAccountContext(ctx context.Context) (*Account, error) {
+	req, err := c.newRequest(ctx, "GET", "/v1/account")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.doRequest(req)
 	if err != nil {
 		return nil, err
 	}
 
+	var item Account
+
+	err = c.unmarshal(req, res, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// CreateAccount signs up a new account for the given email.
+func (c *Client) CreateAccount(email string, opts ...RequestOption) (*Account, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.CreateAccountContext(ctx, email)
+}
+
+func (c *Client) CreateAccountContext(ctx context.Context, email string) (*Account, error) {
+	params := url.Values{}
+	params.Add("email", email)
+
+	return c.sendAccount(ctx, "POST", params)
+}
+
+func (c *Client) UpdateAccount(parameters AccountParameters, opts ...RequestOption) (*Account, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.UpdateAccountContext(ctx, parameters)
+}
+
+func (c *Client) UpdateAccountContext(ctx context.Context, parameters AccountParameters) (*Account, error) {
+	params, err := encodeForm(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.sendAccount(ctx, "PUT", params)
+}
+
+func (c *Client) sendAccount(ctx context.Context, method string, params url.Values) (*Account, error) {
+	req, err := c.newRequest(ctx, method, "/v1/account")
+	if err != nil {
+		return nil, err
+	}
+
+	setFormBody(req, params)
+
 	res, err := c.doRequest(req)
 	if err != nil {
 		return nil, err
@@ -33,7 +104,7 @@ func (c *Client) GetAccount() (*Account, error) {
 
 	var item Account
 
-	err = json.Unmarshal(res, &item)
+	err = c.unmarshal(req, res, &item)
 	if err != nil {
 		return nil, err
 	}
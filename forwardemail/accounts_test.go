@@ -2,6 +2,7 @@ package forwardemail
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -45,8 +46,8 @@ func TestClient_GetAccount(t *testing.T) {
 				Id:             "59ad551ae6fb4a4c53427ca38079f029",
 				Object:         "user",
 				Locale:         "en",
-				CreatedAt:      parseTime("2023-09-21T20:14:27.964Z"),
-				UpdatedAt:      parseTime("2023-10-07T17:47:54.595Z"),
+				CreatedAt:      Timestamp{parseTime("2023-09-21T20:14:27.964Z")},
+				UpdatedAt:      Timestamp{parseTime("2023-10-07T17:47:54.595Z")},
 			},
 		},
 	}
@@ -75,3 +76,115 @@ func parseTime(str string) time.Time {
 
 	return t
 }
+
+func TestClient_CreateAccount(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		res      string
+		wantBody string
+		want     *Account
+	}{
+		{
+			name:     "no data",
+			wantBody: "email=",
+		},
+		{
+			name:     "ok",
+			email:    "pepper@potts.com",
+			res:      `{"email": "pepper@potts.com", "id": "6525b03e0bde8f333ace5824", "object": "user"}`,
+			wantBody: "email=pepper%40potts.com",
+			want: &Account{
+				Email:  "pepper@potts.com",
+				Id:     "6525b03e0bde8f333ace5824",
+				Object: "user",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var method, body string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				method = r.Method
+				b, _ := io.ReadAll(r.Body)
+				body = string(b)
+
+				fmt.Fprint(w, tt.res)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, _ := c.CreateAccount(tt.email)
+			if diff := cmp.Diff("POST", method); diff != "" {
+				t.Fatalf("methods are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.wantBody, body); diff != "" {
+				t.Fatalf("request bodies are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_UpdateAccount(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   AccountParameters
+		res      string
+		wantBody string
+		want     *Account
+	}{
+		{
+			name: "no data",
+		},
+		{
+			name: "ok",
+			params: AccountParameters{
+				GivenName:  pointString("Tony"),
+				FamilyName: pointString("Stark"),
+			},
+			res:      `{"email": "tony@stark.com", "given_name": "Tony", "family_name": "Stark"}`,
+			wantBody: "family_name=Stark&given_name=Tony",
+			want: &Account{
+				Email:      "tony@stark.com",
+				GivenName:  "Tony",
+				FamilyName: "Stark",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var method, body string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				method = r.Method
+				b, _ := io.ReadAll(r.Body)
+				body = string(b)
+
+				fmt.Fprint(w, tt.res)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, _ := c.UpdateAccount(tt.params)
+			if diff := cmp.Diff("PUT", method); diff != "" {
+				t.Fatalf("methods are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.wantBody, body); diff != "" {
+				t.Fatalf("request bodies are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
@@ -1,10 +1,15 @@
 package forwardemail
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
+	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -55,231 +60,682 @@ func (d *DomainOrID) UnmarshalJSON(data []byte) error {
 }
 
 type Alias struct {
-	User                     AccountOrID `json:"user"`
-	Domain                   DomainOrID  `json:"domain"`
-	Name                     string      `json:"name"`
-	Description              string      `json:"description"`
-	Labels                   []string    `json:"labels"`
-	IsEnabled                bool        `json:"is_enabled"`
-	HasRecipientVerification bool        `json:"has_recipient_verification"`
-	Recipients               []string    `json:"recipients"`
-	Id                       string      `json:"id"`
-	Object                   string      `json:"object"`
-	CreatedAt                time.Time   `json:"created_at"`
-	UpdatedAt                time.Time   `json:"updated_at"`
+	User                     AccountOrID       `json:"user"`
+	Domain                   DomainOrID        `json:"domain"`
+	Name                     string            `json:"name"`
+	Description              string            `json:"description"`
+	Labels                   []string          `json:"labels"`
+	IsEnabled                bool              `json:"is_enabled"`
+	ErrorCodeIfDisabled      ErrorCode         `json:"error_code_if_disabled"`
+	HasRecipientVerification bool              `json:"has_recipient_verification"`
+	Recipients               []string          `json:"recipients"`
+	VerifiedRecipients       []string          `json:"verified_recipients"`
+	PendingRecipients        []string          `json:"pending_recipients"`
+	HasIMAP                  bool              `json:"has_imap"`
+	HasPGP                   bool              `json:"has_pgp"`
+	PublicKey                string            `json:"public_key"`
+	MaxQuota                 int64             `json:"max_quota"`
+	StorageUsed              int64             `json:"storage_used"`
+	VacationResponder        VacationResponder `json:"vacation_responder"`
+	Id                       string            `json:"id"`
+	Object                   string            `json:"object"`
+	CreatedAt                Timestamp         `json:"created_at"`
+	UpdatedAt                Timestamp         `json:"updated_at"`
 }
 
-type AliasParameters struct {
-	Recipients               *[]string
-	Description              string `json:"description"`
-	Labels                   *[]string
-	HasRecipientVerification *bool
-	IsEnabled                *bool
+// ErrorCode is the SMTP response a disabled alias gives to the mail sent to it.
+type ErrorCode int
+
+const (
+	// ErrorCodeAccept accepts the mail with a 250 response and silently drops it.
+	ErrorCodeAccept ErrorCode = 250
+	// ErrorCodeSoftReject rejects the mail with a 421 response, so the sender retries later.
+	ErrorCodeSoftReject ErrorCode = 421
+	// ErrorCodeHardReject rejects the mail with a 550 response, bouncing it to the sender.
+	ErrorCodeHardReject ErrorCode = 550
+)
+
+// Valid reports whether c is one of the error codes accepted by the API.
+func (c ErrorCode) Valid() bool {
+	switch c {
+	case ErrorCodeAccept, ErrorCodeSoftReject, ErrorCodeHardReject:
+		return true
+	default:
+		return false
+	}
 }
 
-type GeneratePasswordParameters struct {
-	NewPassword         *string
-	Password            *string
-	IsOverride          *bool
-	EmailedInstructions *string
+type VacationResponder struct {
+	IsEnabled bool       `json:"is_enabled"`
+	StartDate *Timestamp `json:"start_date"`
+	EndDate   *Timestamp `json:"end_date"`
+	Subject   string     `json:"subject"`
+	Message   string     `json:"message"`
 }
 
-type GeneratedPassword struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+type RecipientStatus struct {
+	Recipient string
+	Verified  bool
+	// Pending is true when a verification email was sent to the recipient, who hasn't
+	// confirmed yet.
+	Pending bool
 }
 
-func (c *Client) GetAliases(domain string) ([]Alias, error) {
-	req, err := c.newRequest("GET", fmt.Sprintf("/v1/domains/%s/aliases", domain))
-	if err != nil {
-		return nil, err
+// RecipientStatuses returns the verification state of every recipient of the alias. When
+// recipient verification is disabled, all recipients are reported as verified.
+func (a *Alias) RecipientStatuses() []RecipientStatus {
+	verified := make(map[string]bool, len(a.VerifiedRecipients))
+	for _, r := range a.VerifiedRecipients {
+		verified[strings.ToLower(r)] = true
+	}
+	pending := make(map[string]bool, len(a.PendingRecipients))
+	for _, r := range a.PendingRecipients {
+		pending[strings.ToLower(r)] = true
 	}
 
-	res, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
+	statuses := make([]RecipientStatus, 0, len(a.Recipients))
+	for _, r := range a.Recipients {
+		isVerified := !a.HasRecipientVerification || verified[strings.ToLower(r)]
+		statuses = append(statuses, RecipientStatus{
+			Recipient: r,
+			Verified:  isVerified,
+			Pending:   !isVerified && pending[strings.ToLower(r)],
+		})
 	}
 
-	var items []Alias
+	return statuses
+}
 
-	err = json.Unmarshal(res, &items)
-	if err != nil {
-		return nil, err
+// AliasParameters are sent as a JSON body, so supporting a new API field only needs a tagged field here.
+//
+// Each field may be left unchanged (nil, or an empty Description), set, or cleared by listing
+// its JSON name in Clear, as in Clear: []string{"description", "vacation_responder_end_date"}.
+type AliasParameters struct {
+	Recipients               *[]string  `json:"recipients,omitempty"`
+	Description              string     `json:"description,omitempty"`
+	Labels                   *[]string  `json:"labels,omitempty"`
+	HasRecipientVerification *bool      `json:"has_recipient_verification,omitempty"`
+	IsEnabled                *bool      `json:"is_enabled,omitempty"`
+	ErrorCodeIfDisabled      *ErrorCode `json:"error_code_if_disabled,omitempty"`
+
+	HasIMAP   *bool   `json:"has_imap,omitempty"`
+	HasPGP    *bool   `json:"has_pgp,omitempty"`
+	PublicKey *string `json:"public_key,omitempty"`
+	MaxQuota  *int64  `json:"max_quota,omitempty"`
+
+	VacationResponderIsEnabled *bool      `json:"vacation_responder_is_enabled,omitempty"`
+	VacationResponderStartDate *time.Time `json:"vacation_responder_start_date,omitempty"`
+	VacationResponderEndDate   *time.Time `json:"vacation_responder_end_date,omitempty"`
+	VacationResponderSubject   *string    `json:"vacation_responder_subject,omitempty"`
+	VacationResponderMessage   *string    `json:"vacation_responder_message,omitempty"`
+
+	// Clear lists the JSON names of the fields to reset, which are sent as null. A cleared
+	// field must be left unset.
+	Clear []string `json:"-"`
+}
+
+// aliasFields returns the JSON names of the fields of AliasParameters, which Clear accepts.
+func aliasFields() map[string]bool {
+	fields := map[string]bool{}
+	rt := reflect.TypeOf(AliasParameters{})
+	for i := 0; i < rt.NumField(); i++ {
+		name, _, _ := strings.Cut(rt.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
 	}
 
-	return items, nil
+	return fields
 }
 
-func (c *Client) GetAlias(domain string, alias string) (*Alias, error) {
-	req, err := c.newRequest("GET", fmt.Sprintf("/v1/domains/%s/aliases/%s", domain, alias))
-	if err != nil {
-		return nil, err
+// aliasBody is the JSON body of alias create and update requests.
+type aliasBody struct {
+	Name string `json:"name,omitempty"`
+	AliasParameters
+}
+
+func (b aliasBody) MarshalJSON() ([]byte, error) {
+	type body aliasBody
+	data, err := json.Marshal(body(b))
+	if err != nil || len(b.Clear) == 0 {
+		return data, err
 	}
 
-	res, err := c.doRequest(req)
-	if err != nil {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
 		return nil, err
 	}
+	for _, name := range b.Clear {
+		fields[name] = json.RawMessage("null")
+	}
 
-	var item Alias
+	return json.Marshal(fields)
+}
 
-	err = json.Unmarshal(res, &item)
-	if err != nil {
-		return nil, err
-	}
+type GeneratePasswordParameters struct {
+	NewPassword         *string `form:"new_password"`
+	Password            *string `form:"password"`
+	IsOverride          *bool   `form:"is_override"`
+	EmailedInstructions *string `form:"emailed_instructions"`
+
+	// LocalPassword, when set, has the new password generated by the library following the
+	// policy rather than by the API, for compliance regimes requiring client-side generation.
+	// It is sent as NewPassword, which must be left nil, and returned in GeneratedPassword.
+	LocalPassword *PasswordPolicy `form:"-"`
+}
 
-	return &item, nil
+// GeneratedPassword is the result of GenerateAliasPassword. The server settings are only
+// set when the deployment returns them.
+type GeneratedPassword struct {
+	Username string          `json:"username"`
+	Password string          `json:"password"`
+	IMAP     *ServerEndpoint `json:"imap,omitempty"`
+	SMTP     *ServerEndpoint `json:"smtp,omitempty"`
+	CalDAV   *ServerEndpoint `json:"caldav,omitempty"`
+
+	// Extra keeps the other fields of the payload, unknown to this package. It isn't
+	// marshalled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
-func (c *Client) CreateAlias(domain string, alias string, parameters AliasParameters) (*Alias, error) {
-	req, err := c.newRequest("POST", fmt.Sprintf("/v1/domains/%s/aliases", domain))
-	if err != nil {
-		return nil, err
+// ServerEndpoint is where a mail client connects to use an alias password.
+type ServerEndpoint struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+func (p *GeneratedPassword) UnmarshalJSON(data []byte) error {
+	type generatedPassword GeneratedPassword
+	var known generatedPassword
+	if err := json.Unmarshal(data, &known); err != nil {
+		return err
 	}
 
-	params := url.Values{}
-	params.Add("name", alias)
-	if parameters.Description != "" {
-		params.Add("description", parameters.Description)
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	// The keys of the fields above.
+	for _, key := range []string{"username", "password", "imap", "smtp", "caldav"} {
+		delete(payload, key)
 	}
 
-	for k, v := range map[string]*bool{
-		"has_recipient_verification": parameters.HasRecipientVerification,
-		"is_enabled":                 parameters.IsEnabled,
-	} {
-		if v != nil {
-			params.Add(k, strconv.FormatBool(*v))
-		}
+	*p = GeneratedPassword(known)
+	if len(payload) > 0 {
+		p.Extra = payload
 	}
 
-	for k, v := range map[string]*[]string{
-		"recipients[]": parameters.Recipients,
-		"labels[]":     parameters.Labels,
-	} {
-		if v != nil {
-			for _, vv := range *v {
-				params.Add(k, vv)
-			}
+	return nil
+}
+
+type ListAliasParameters struct {
+	ListOptions
+
+	// Name matches the alias name exactly and Recipient any alias forwarding to it.
+	Name      string
+	Recipient string
+	Label     string
+	IsEnabled *bool
+
+	// SortField and SortOrder take precedence over ListOptions.Sort when set.
+	SortField string
+	SortOrder string // "asc" or "desc"
+}
+
+// GetAliasesOptions is the set of server-side filters accepted when listing aliases.
+type GetAliasesOptions = ListAliasParameters
+
+func (c *Client) GetAliases(domain string, opts ...RequestOption) ([]Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetAliasesContext(ctx, domain)
+}
+
+func (c *Client) GetAliasesContext(ctx context.Context, domain string) ([]Alias, error) {
+	return c.GetAliasesFilteredContext(ctx, domain, ListAliasParameters{})
+}
+
+// GetAliasesFiltered lists the aliases of a domain matching the given parameters,
+// leaving the filtering and sorting to the API.
+func (c *Client) GetAliasesFiltered(domain string, parameters ListAliasParameters, opts ...RequestOption) ([]Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetAliasesFilteredContext(ctx, domain, parameters)
+}
+
+func (c *Client) GetAliasesFilteredContext(ctx context.Context, domain string, parameters ListAliasParameters) ([]Alias, error) {
+	items, _, err := c.GetAliasesPageContext(ctx, domain, parameters)
+
+	return items, err
+}
+
+// GetAliasesPage returns a single page of aliases along with the pagination details.
+func (c *Client) GetAliasesPage(domain string, parameters ListAliasParameters, opts ...RequestOption) ([]Alias, *Pagination, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetAliasesPageContext(ctx, domain, parameters)
+}
+
+func (c *Client) GetAliasesPageContext(ctx context.Context, domain string, parameters ListAliasParameters) ([]Alias, *Pagination, error) {
+	return list[Alias](ctx, c, pathf("/v1/domains/%s/aliases", domain), parameters.values())
+}
+
+// GetAliasesFunc calls fn with every alias of a domain, following every page. Aliases are
+// decoded one at a time as the responses are read, so even domains with tens of thousands
+// of aliases are processed in constant memory. An error from fn stops the iteration and is
+// returned as is.
+func (c *Client) GetAliasesFunc(domain string, fn func(Alias) error, opts ...RequestOption) error {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetAliasesFuncContext(ctx, domain, fn)
+}
+
+func (c *Client) GetAliasesFuncContext(ctx context.Context, domain string, fn func(Alias) error) error {
+	for page := 1; ; page++ {
+		path := pathf("/v1/domains/%s/aliases", domain) + "?" + ListAliasParameters{ListOptions: ListOptions{Page: page}}.values().Encode()
+
+		req, err := c.newRequest(ctx, "GET", path)
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		header, err := c.doStreamRequest(req, func(r io.Reader) error {
+			_, err := decodeJSONArray(c.newDecoder(r), func(item Alias) error {
+				count++
+				return fn(item)
+			})
+
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		if count == 0 || !parsePagination(header).HasNextPage() {
+			return nil
 		}
 	}
+}
 
-	req.Body = io.NopCloser(strings.NewReader(params.Encode()))
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+// GetAllAliases follows every page starting from parameters.Page and returns all matching aliases.
+func (c *Client) GetAllAliases(domain string, parameters ListAliasParameters, opts ...RequestOption) ([]Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
 
-	res, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
+	return c.GetAllAliasesContext(ctx, domain, parameters)
+}
+
+func (c *Client) GetAllAliasesContext(ctx context.Context, domain string, parameters ListAliasParameters) ([]Alias, error) {
+	return collectPages(parameters.Page, func(page int) ([]Alias, *Pagination, error) {
+		parameters.Page = page
+		return c.GetAliasesPageContext(ctx, domain, parameters)
+	})
+}
+
+func (p ListAliasParameters) values() url.Values {
+	params := p.ListOptions.values()
+	if p.Name != "" {
+		params.Add("name", p.Name)
+	}
+	if p.Recipient != "" {
+		params.Add("recipient", p.Recipient)
+	}
+	if p.Label != "" {
+		params.Add("labels", p.Label)
+	}
+	if p.IsEnabled != nil {
+		params.Add("is_enabled", strconv.FormatBool(*p.IsEnabled))
+	}
+	if p.SortField != "" {
+		params.Set("sort", sortValue(p.SortField, p.SortOrder))
 	}
 
-	var item Alias
+	return params
+}
 
-	err = json.Unmarshal(res, &item)
-	if err != nil {
-		return nil, err
+func (c *Client) GetAlias(domain string, alias string, opts ...RequestOption) (*Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetAliasContext(ctx, domain, alias)
+}
+
+func (c *Client) GetAliasContext(ctx context.Context, domain string, alias string) (*Alias, error) {
+	return do[Alias](ctx, c, "GET", pathf("/v1/domains/%s/aliases/%s", domain, alias), nil)
+}
+
+// GetAliasByID returns the alias with the given ID, the 24 hexadecimal characters of Alias.Id.
+// Unlike GetAlias, the value can never be mistaken for an alias name.
+func (c *Client) GetAliasByID(domain string, id string, opts ...RequestOption) (*Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetAliasByIDContext(ctx, domain, id)
+}
+
+func (c *Client) GetAliasByIDContext(ctx context.Context, domain string, id string) (*Alias, error) {
+	if !isObjectID(id) {
+		return nil, fmt.Errorf("alias id %q is not a valid id", id)
 	}
 
-	return &item, nil
+	return c.GetAliasContext(ctx, domain, id)
 }
 
-func (c *Client) UpdateAlias(domain string, alias string, parameters AliasParameters) (*Alias, error) {
-	req, err := c.newRequest("PUT", fmt.Sprintf("/v1/domains/%s/aliases/%s", domain, alias))
-	if err != nil {
-		return nil, err
+// GetAliasesByIDs looks up several aliases by ID, concurrently as set by options, and returns
+// one result per ID in the same order, named after the ID. The API can't filter the alias list
+// by ID, so every distinct ID costs one request: repeated IDs share it.
+func (c *Client) GetAliasesByIDs(domain string, ids []string, options BulkOptions, opts ...RequestOption) BulkResults {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetAliasesByIDsContext(ctx, domain, ids, options)
+}
+
+func (c *Client) GetAliasesByIDsContext(ctx context.Context, domain string, ids []string, options BulkOptions) BulkResults {
+	var distinct []string
+	seen := map[string]int{}
+	for _, id := range ids {
+		if _, ok := seen[id]; !ok {
+			seen[id] = len(distinct)
+			distinct = append(distinct, id)
+		}
 	}
 
-	params := url.Values{}
-	params.Add("name", alias)
-	if parameters.Description != "" {
-		params.Add("description", parameters.Description)
+	found := runBulk(ctx, options, distinct, func(i int) BulkResult {
+		item, err := c.GetAliasByIDContext(ctx, domain, distinct[i])
+		return BulkResult{Name: distinct[i], Alias: item, Err: err}
+	})
+
+	results := make(BulkResults, len(ids))
+	for i, id := range ids {
+		results[i] = found[seen[id]]
 	}
 
-	for k, v := range map[string]*bool{
-		"has_recipient_verification": parameters.HasRecipientVerification,
-		"is_enabled":                 parameters.IsEnabled,
-	} {
-		if v != nil {
-			params.Add(k, strconv.FormatBool(*v))
-		}
+	return results
+}
+
+// GetAliasByName returns the alias with exactly the given name, looked up through the alias
+// list so that names containing dots or plus signs are never interpreted as IDs. When there
+// is no such alias, the error satisfies IsNotFound.
+func (c *Client) GetAliasByName(domain string, name string, opts ...RequestOption) (*Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetAliasByNameContext(ctx, domain, name)
+}
+
+func (c *Client) GetAliasByNameContext(ctx context.Context, domain string, name string) (*Alias, error) {
+	items, err := c.GetAliasesFilteredContext(ctx, domain, ListAliasParameters{Name: name})
+	if err != nil {
+		return nil, err
 	}
 
-	for k, v := range map[string]*[]string{
-		"recipients[]": parameters.Recipients,
-		"labels[]":     parameters.Labels,
-	} {
-		if v != nil {
-			for _, vv := range *v {
-				params.Add(k, vv)
-			}
+	for i := range items {
+		if strings.EqualFold(items[i].Name, name) {
+			return &items[i], nil
 		}
 	}
 
-	req.Body = io.NopCloser(strings.NewReader(params.Encode()))
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// The list endpoint has no 404 of its own, so the error is shaped like one of the API.
+	message := fmt.Sprintf("alias %q does not exist", name)
+	return nil, &APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    message,
+		Body:       []byte(message),
+	}
+}
 
-	res, err := c.doRequest(req)
-	if err != nil {
+var objectIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{24}$`)
+
+func isObjectID(id string) bool {
+	return objectIDPattern.MatchString(id)
+}
+
+func (c *Client) CreateAlias(domain string, alias string, parameters AliasParameters, opts ...RequestOption) (*Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.CreateAliasContext(ctx, domain, alias, parameters)
+}
+
+func (c *Client) CreateAliasContext(ctx context.Context, domain string, alias string, parameters AliasParameters) (*Alias, error) {
+	if err := validateAlias(alias, parameters); err != nil {
 		return nil, err
 	}
 
-	var item Alias
+	return do[Alias](ctx, c, "POST", pathf("/v1/domains/%s/aliases", domain), aliasBody{Name: alias, AliasParameters: parameters})
+}
 
-	err = json.Unmarshal(res, &item)
-	if err != nil {
+// CatchAllAliasName is the alias name matching every address of a domain without a more specific alias.
+const CatchAllAliasName = "*"
+
+// CreateCatchAllAlias creates the catch-all alias of a domain.
+func (c *Client) CreateCatchAllAlias(domain string, parameters AliasParameters, opts ...RequestOption) (*Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.CreateCatchAllAliasContext(ctx, domain, parameters)
+}
+
+func (c *Client) CreateCatchAllAliasContext(ctx context.Context, domain string, parameters AliasParameters) (*Alias, error) {
+	return c.CreateAliasContext(ctx, domain, CatchAllAliasName, parameters)
+}
+
+// CreateRegexAlias creates an alias matching the addresses of a domain against a regular
+// expression, written between slashes and optionally followed by flags, e.g. "/^support-.+$/i".
+// The pattern is checked before anything is sent.
+func (c *Client) CreateRegexAlias(domain string, pattern string, parameters AliasParameters, opts ...RequestOption) (*Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.CreateRegexAliasContext(ctx, domain, pattern, parameters)
+}
+
+func (c *Client) CreateRegexAliasContext(ctx context.Context, domain string, pattern string, parameters AliasParameters) (*Alias, error) {
+	if err := ValidateRegexAliasName(pattern); err != nil {
 		return nil, err
 	}
 
-	return &item, nil
+	return c.CreateAliasContext(ctx, domain, pattern, parameters)
 }
 
-func (c *Client) DeleteAlias(domain string, alias string) error {
-	req, err := c.newRequest("DELETE", fmt.Sprintf("/v1/domains/%s/aliases/%s", domain, alias))
-	if err != nil {
-		return err
+// ValidateRegexAliasName checks that name is a regex alias name the API accepts. The expression
+// is compiled with the regexp package, so constructs RE2 doesn't support, such as lookarounds
+// and backreferences, are rejected even though the API would accept them.
+func ValidateRegexAliasName(name string) error {
+	end := strings.LastIndex(name, "/")
+	if !strings.HasPrefix(name, "/") || end < 1 {
+		return fmt.Errorf("regex alias %q must be enclosed in slashes", name)
 	}
 
-	_, err = c.doRequest(req)
-	if err != nil {
-		return err
+	expr, flags := name[1:end], name[end+1:]
+	if expr == "" {
+		return fmt.Errorf("regex alias %q has an empty expression", name)
+	}
+
+	var goFlags string
+	for _, flag := range flags {
+		switch flag {
+		case 'i', 'm', 's':
+			goFlags += string(flag)
+		case 'g', 'u', 'y':
+		default:
+			return fmt.Errorf("regex alias %q has an unknown flag %q", name, flag)
+		}
+	}
+	if goFlags != "" {
+		expr = "(?" + goFlags + ")" + expr
+	}
+
+	if _, err := regexp.Compile(expr); err != nil {
+		return fmt.Errorf("regex alias %q is invalid: %w", name, err)
 	}
 
 	return nil
 }
 
-func (c *Client) GenerateAliasPassword(domain string, alias string, parameters GeneratePasswordParameters) (*GeneratedPassword, error) {
-	req, err := c.newRequest("POST", fmt.Sprintf("/v1/domains/%s/aliases/%s/generate-password", domain, alias))
-	if err != nil {
+// UpdateAlias performs a partial update of an alias: the API only changes the fields present in
+// the request, so any field left unset in parameters (nil pointers, empty Description) keeps
+// its current value and, for example, a nil Recipients never clears the recipients of the
+// alias. Fields are only reset when listed in parameters.Clear. As only the given fields are
+// sent, concurrent updates of other fields aren't overwritten.
+func (c *Client) UpdateAlias(domain string, alias string, parameters AliasParameters, opts ...RequestOption) (*Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.UpdateAliasContext(ctx, domain, alias, parameters)
+}
+
+func (c *Client) UpdateAliasContext(ctx context.Context, domain string, alias string, parameters AliasParameters) (*Alias, error) {
+	if err := validateAlias(alias, parameters); err != nil {
+		return nil, err
+	}
+
+	return c.putAlias(ctx, domain, alias, aliasBody{AliasParameters: parameters})
+}
+
+// UpsertAlias creates the alias when it does not exist yet and updates it otherwise, returning
+// the resulting alias. If another client creates the alias between the existence check and the
+// create, the "already exists" error from the API is turned into an update.
+func (c *Client) UpsertAlias(domain string, alias string, parameters AliasParameters, opts ...RequestOption) (*Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.UpsertAliasContext(ctx, domain, alias, parameters)
+}
+
+func (c *Client) UpsertAliasContext(ctx context.Context, domain string, alias string, parameters AliasParameters) (*Alias, error) {
+	if err := validateAlias(alias, parameters); err != nil {
 		return nil, err
 	}
 
-	params := url.Values{}
+	_, err := c.GetAliasContext(ctx, domain, alias)
+	if err == nil {
+		return c.putAlias(ctx, domain, alias, aliasBody{AliasParameters: parameters})
+	}
+	if !IsNotFound(err) {
+		return nil, err
+	}
 
-	if parameters.NewPassword != nil {
-		params.Add("new_password", *parameters.NewPassword)
+	item, err := c.CreateAliasContext(ctx, domain, alias, parameters)
+	if err == nil || !isAlreadyExists(err) {
+		return item, err
 	}
-	if parameters.Password != nil {
-		params.Add("password", *parameters.Password)
+
+	return c.UpdateAliasContext(ctx, domain, alias, parameters)
+}
+
+func isAlreadyExists(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusBadRequest && strings.Contains(strings.ToLower(apiErr.Message), "already exists")
 	}
-	if parameters.IsOverride != nil {
-		params.Add("is_override", strconv.FormatBool(*parameters.IsOverride))
+
+	return false
+}
+
+// EnableAlias turns an alias on. It is an UpdateAlias that only sends is_enabled, leaving
+// every other field untouched.
+func (c *Client) EnableAlias(domain string, alias string, opts ...RequestOption) (*Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.EnableAliasContext(ctx, domain, alias)
+}
+
+func (c *Client) EnableAliasContext(ctx context.Context, domain string, alias string) (*Alias, error) {
+	return c.setAliasEnabled(ctx, domain, alias, true)
+}
+
+// DisableAlias turns an alias off. It is an UpdateAlias that only sends is_enabled, leaving
+// every other field untouched.
+func (c *Client) DisableAlias(domain string, alias string, opts ...RequestOption) (*Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.DisableAliasContext(ctx, domain, alias)
+}
+
+func (c *Client) DisableAliasContext(ctx context.Context, domain string, alias string) (*Alias, error) {
+	return c.setAliasEnabled(ctx, domain, alias, false)
+}
+
+func (c *Client) setAliasEnabled(ctx context.Context, domain string, alias string, enabled bool) (*Alias, error) {
+	return c.UpdateAliasContext(ctx, domain, alias, AliasParameters{IsEnabled: &enabled})
+}
+
+func (c *Client) putAlias(ctx context.Context, domain string, alias string, body aliasBody) (*Alias, error) {
+	return do[Alias](ctx, c, "PUT", pathf("/v1/domains/%s/aliases/%s", domain, alias), body)
+}
+
+func (c *Client) DeleteAlias(domain string, alias string, opts ...RequestOption) error {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.DeleteAliasContext(ctx, domain, alias)
+}
+
+func (c *Client) DeleteAliasContext(ctx context.Context, domain string, alias string) error {
+	return doDiscard(ctx, c, "DELETE", pathf("/v1/domains/%s/aliases/%s", domain, alias), nil)
+}
+
+func (c *Client) GenerateAliasPassword(domain string, alias string, parameters GeneratePasswordParameters, opts ...RequestOption) (*GeneratedPassword, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GenerateAliasPasswordContext(ctx, domain, alias, parameters)
+}
+
+func (c *Client) GenerateAliasPasswordContext(ctx context.Context, domain string, alias string, parameters GeneratePasswordParameters) (*GeneratedPassword, error) {
+	if err := parameters.validate(); err != nil {
+		return nil, err
 	}
-	if parameters.EmailedInstructions != nil {
-		params.Add("emailed_instructions", *parameters.EmailedInstructions)
+
+	var local string
+	if parameters.LocalPassword != nil {
+		password, err := GeneratePassword(*parameters.LocalPassword)
+		if err != nil {
+			return nil, err
+		}
+		local = password
+		parameters.NewPassword = &local
 	}
 
-	req.Body = io.NopCloser(strings.NewReader(params.Encode()))
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	params, err := encodeForm(parameters)
+	if err != nil {
+		return nil, err
+	}
 
-	res, err := c.doRequest(req)
+	item, err := do[GeneratedPassword](withSecrets(ctx), c, "POST", pathf("/v1/domains/%s/aliases/%s/generate-password", domain, alias), params)
 	if err != nil {
 		return nil, err
 	}
+	if item.Password == "" {
+		item.Password = local
+	}
+
+	return item, nil
+}
+
+// GetAliasRecipientStatus returns the verification state of every recipient of an alias.
+// When recipient verification is disabled on the alias, all recipients are reported as verified.
+func (c *Client) GetAliasRecipientStatus(domain string, alias string, opts ...RequestOption) ([]RecipientStatus, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
 
-	var item GeneratedPassword
+	return c.GetAliasRecipientStatusContext(ctx, domain, alias)
+}
 
-	err = json.Unmarshal(res, &item)
+func (c *Client) GetAliasRecipientStatusContext(ctx context.Context, domain string, alias string) ([]RecipientStatus, error) {
+	item, err := c.GetAliasContext(ctx, domain, alias)
 	if err != nil {
 		return nil, err
 	}
 
-	return &item, nil
+	return item.RecipientStatuses(), nil
 }
@@ -1,12 +1,14 @@
 package forwardemail
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -59,14 +61,20 @@ func TestClient_GetAlias(t *testing.T) {
 				"updated_at": "2023-10-10T20:12:46.588Z"
 			}`,
 			want: &Alias{
-				Account: Account{
-					Email:       "tony@stark.com",
-					DisplayName: "tony@stark.com",
-					Id:          "59ad551ae6fb4a4c53427ca38079f029",
+				User: AccountOrID{
+					Account: &Account{
+						Email:       "tony@stark.com",
+						DisplayName: "tony@stark.com",
+						Id:          "59ad551ae6fb4a4c53427ca38079f029",
+					},
+					ID: "59ad551ae6fb4a4c53427ca38079f029",
 				},
-				Domain: Domain{
-					Name: "stark.com",
-					Id:   "15ff615b6180f1fc7faf40e6",
+				Domain: DomainOrID{
+					Domain: &Domain{
+						Name: "stark.com",
+						Id:   "15ff615b6180f1fc7faf40e6",
+					},
+					ID: "15ff615b6180f1fc7faf40e6",
 				},
 				Name:                     "tony",
 				Description:              "main email",
@@ -76,8 +84,8 @@ func TestClient_GetAlias(t *testing.T) {
 				Recipients:               []string{"james@rhodes.com"},
 				Id:                       "6525b03e0bde8f333ace5824",
 				Object:                   "alias",
-				CreatedAt:                parseTime("2023-10-10T20:12:46.588Z"),
-				UpdatedAt:                parseTime("2023-10-10T20:12:46.588Z"),
+				CreatedAt:                Timestamp{parseTime("2023-10-10T20:12:46.588Z")},
+				UpdatedAt:                Timestamp{parseTime("2023-10-10T20:12:46.588Z")},
 			},
 		},
 	}
@@ -173,14 +181,20 @@ func TestClient_GetAliases(t *testing.T) {
 			]`,
 			want: []Alias{
 				{
-					Account: Account{
-						Email:       "tony@stark.com",
-						DisplayName: "tony@stark.com",
-						Id:          "59ad551ae6fb4a4c53427ca38079f029",
+					User: AccountOrID{
+						Account: &Account{
+							Email:       "tony@stark.com",
+							DisplayName: "tony@stark.com",
+							Id:          "59ad551ae6fb4a4c53427ca38079f029",
+						},
+						ID: "59ad551ae6fb4a4c53427ca38079f029",
 					},
-					Domain: Domain{
-						Name: "stark.com",
-						Id:   "15ff615b6180f1fc7faf40e6",
+					Domain: DomainOrID{
+						Domain: &Domain{
+							Name: "stark.com",
+							Id:   "15ff615b6180f1fc7faf40e6",
+						},
+						ID: "15ff615b6180f1fc7faf40e6",
 					},
 					Name:                     "tony",
 					Description:              "main email",
@@ -190,18 +204,24 @@ func TestClient_GetAliases(t *testing.T) {
 					Recipients:               []string{"james@rhodes.com"},
 					Id:                       "6525b03e0bde8f333ace5824",
 					Object:                   "alias",
-					CreatedAt:                parseTime("2023-10-10T20:12:46.588Z"),
-					UpdatedAt:                parseTime("2023-10-10T20:12:46.588Z"),
+					CreatedAt:                Timestamp{parseTime("2023-10-10T20:12:46.588Z")},
+					UpdatedAt:                Timestamp{parseTime("2023-10-10T20:12:46.588Z")},
 				},
 				{
-					Account: Account{
-						Email:       "tony@stark.com",
-						DisplayName: "tony@stark.com",
-						Id:          "59ad551ae6fb4a4c53427ca38079f029",
+					User: AccountOrID{
+						Account: &Account{
+							Email:       "tony@stark.com",
+							DisplayName: "tony@stark.com",
+							Id:          "59ad551ae6fb4a4c53427ca38079f029",
+						},
+						ID: "59ad551ae6fb4a4c53427ca38079f029",
 					},
-					Domain: Domain{
-						Name: "stark.com",
-						Id:   "15ff615b6180f1fc7faf40e6",
+					Domain: DomainOrID{
+						Domain: &Domain{
+							Name: "stark.com",
+							Id:   "15ff615b6180f1fc7faf40e6",
+						},
+						ID: "15ff615b6180f1fc7faf40e6",
 					},
 					Name:                     "james",
 					Labels:                   []string{"catch-all"},
@@ -210,8 +230,8 @@ func TestClient_GetAliases(t *testing.T) {
 					Recipients:               []string{"james@rhodes.com"},
 					Id:                       "b078f60f2636c4d6cf668d9b36a3e42e",
 					Object:                   "alias",
-					CreatedAt:                parseTime("2023-10-12T18:11:22.123Z"),
-					UpdatedAt:                parseTime("2023-10-12T19:55:56.534Z"),
+					CreatedAt:                Timestamp{parseTime("2023-10-12T18:11:22.123Z")},
+					UpdatedAt:                Timestamp{parseTime("2023-10-12T19:55:56.534Z")},
 				},
 			},
 		},
@@ -291,14 +311,20 @@ func TestClient_CreateAlias(t *testing.T) {
 				"updated_at": "2023-11-11T22:12:42.533Z"
 			}`,
 			want: &Alias{
-				Account: Account{
-					Email:       "tony@stark.com",
-					DisplayName: "tony@stark.com",
-					Id:          "59ad551ae6fb4a4c53427ca38079f029",
+				User: AccountOrID{
+					Account: &Account{
+						Email:       "tony@stark.com",
+						DisplayName: "tony@stark.com",
+						Id:          "59ad551ae6fb4a4c53427ca38079f029",
+					},
+					ID: "59ad551ae6fb4a4c53427ca38079f029",
 				},
-				Domain: Domain{
-					Name: "stark.com",
-					Id:   "15ff615b6180f1fc7faf40e6",
+				Domain: DomainOrID{
+					Domain: &Domain{
+						Name: "stark.com",
+						Id:   "15ff615b6180f1fc7faf40e6",
+					},
+					ID: "15ff615b6180f1fc7faf40e6",
 				},
 				Name:                     "*",
 				Description:              "main email",
@@ -308,8 +334,8 @@ func TestClient_CreateAlias(t *testing.T) {
 				Recipients:               []string{"james@rhodes.com"},
 				Id:                       "6525b03e0bde8f333ace5824",
 				Object:                   "alias",
-				CreatedAt:                parseTime("2023-10-10T20:12:46.588Z"),
-				UpdatedAt:                parseTime("2023-11-11T22:12:42.533Z"),
+				CreatedAt:                Timestamp{parseTime("2023-10-10T20:12:46.588Z")},
+				UpdatedAt:                Timestamp{parseTime("2023-11-11T22:12:42.533Z")},
 			},
 		},
 	}
@@ -391,14 +417,20 @@ func TestClient_UpdateAlias(t *testing.T) {
 				"updated_at": "2023-11-11T22:12:42.533Z"
 			}`,
 			want: &Alias{
-				Account: Account{
-					Email:       "tony@stark.com",
-					DisplayName: "tony@stark.com",
-					Id:          "59ad551ae6fb4a4c53427ca38079f029",
+				User: AccountOrID{
+					Account: &Account{
+						Email:       "tony@stark.com",
+						DisplayName: "tony@stark.com",
+						Id:          "59ad551ae6fb4a4c53427ca38079f029",
+					},
+					ID: "59ad551ae6fb4a4c53427ca38079f029",
 				},
-				Domain: Domain{
-					Name: "stark.com",
-					Id:   "15ff615b6180f1fc7faf40e6",
+				Domain: DomainOrID{
+					Domain: &Domain{
+						Name: "stark.com",
+						Id:   "15ff615b6180f1fc7faf40e6",
+					},
+					ID: "15ff615b6180f1fc7faf40e6",
 				},
 				Name:                     "james",
 				Description:              "main email",
@@ -408,8 +440,8 @@ func TestClient_UpdateAlias(t *testing.T) {
 				Recipients:               []string{"james@rhodes.com"},
 				Id:                       "6525b03e0bde8f333ace5824",
 				Object:                   "alias",
-				CreatedAt:                parseTime("2023-10-10T20:12:46.588Z"),
-				UpdatedAt:                parseTime("2023-11-11T22:12:42.533Z"),
+				CreatedAt:                Timestamp{parseTime("2023-10-10T20:12:46.588Z")},
+				UpdatedAt:                Timestamp{parseTime("2023-11-11T22:12:42.533Z")},
 			},
 		},
 	}
@@ -463,7 +495,7 @@ func TestClient_DeleteAlias(t *testing.T) {
 				code: http.StatusInternalServerError,
 				body: "oh no",
 			},
-			want: fmt.Errorf("status: 500, body: oh no"),
+			want: &APIError{StatusCode: http.StatusInternalServerError, Message: "oh no", Body: []byte("oh no")},
 		},
 	}
 
@@ -556,6 +588,41 @@ func TestClient_GenerateAliasPassword(t *testing.T) {
 				Password: "my-custom-password",
 			},
 		},
+		{
+			name: "with extra fields",
+			req: request{
+				domain: "stark.com",
+				alias:  "tony",
+			},
+			res: `{
+				"username": "tony@stark.com",
+				"password": "hKJO_0vJSy!L0Bzm,Hj0",
+				"imap": {"host": "imap.forwardemail.net", "port": 993},
+				"smtp": {"host": "smtp.forwardemail.net", "port": 465},
+				"pop3_port": 995
+			}`,
+			want: &GeneratedPassword{
+				Username: "tony@stark.com",
+				Password: "hKJO_0vJSy!L0Bzm,Hj0",
+				IMAP:     &ServerEndpoint{Host: "imap.forwardemail.net", Port: 993},
+				SMTP:     &ServerEndpoint{Host: "smtp.forwardemail.net", Port: 465},
+				Extra:    map[string]json.RawMessage{"pop3_port": json.RawMessage("995")},
+			},
+		},
+		{
+			name: "invalid emailed instructions",
+			req: request{
+				domain: "stark.com",
+				alias:  "tony",
+				params: GeneratePasswordParameters{
+					EmailedInstructions: pointString("Tony <tony@stark.com>"),
+				},
+			},
+			res: `{
+				"username": "tony@stark.com",
+				"password": "hKJO_0vJSy!L0Bzm,Hj0"
+			}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -576,3 +643,852 @@ func TestClient_GenerateAliasPassword(t *testing.T) {
 		})
 	}
 }
+
+func TestGeneratedPassword_JSON(t *testing.T) {
+	password := GeneratedPassword{
+		Username: "tony@stark.com",
+		Password: "hKJO_0vJSy!L0Bzm,Hj0",
+		CalDAV:   &ServerEndpoint{Host: "caldav.forwardemail.net", Port: 443},
+		Extra:    map[string]json.RawMessage{"pop3_port": json.RawMessage("995")},
+	}
+
+	data, err := json.Marshal(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"username":"tony@stark.com","password":"hKJO_0vJSy!L0Bzm,Hj0","caldav":{"host":"caldav.forwardemail.net","port":443}}`
+	if diff := cmp.Diff(want, string(data)); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	var got GeneratedPassword
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	password.Extra = nil
+	if diff := cmp.Diff(password, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestClient_GetAliasRecipientStatus(t *testing.T) {
+	type request struct {
+		domain string
+		alias  string
+	}
+
+	tests := []struct {
+		name string
+		req  request
+		res  string
+		want []RecipientStatus
+	}{
+		{
+			name: "no data",
+		},
+		{
+			name: "verification disabled",
+			req: request{
+				domain: "stark.com",
+				alias:  "tony",
+			},
+			res: `{
+				"name": "tony",
+				"has_recipient_verification": false,
+				"recipients": [
+				  "james@rhodes.com",
+				  "pepper@potts.com"
+				]
+			}`,
+			want: []RecipientStatus{
+				{Recipient: "james@rhodes.com", Verified: true},
+				{Recipient: "pepper@potts.com", Verified: true},
+			},
+		},
+		{
+			name: "verification enabled",
+			req: request{
+				domain: "stark.com",
+				alias:  "tony",
+			},
+			res: `{
+				"name": "tony",
+				"has_recipient_verification": true,
+				"recipients": [
+				  "james@rhodes.com",
+				  "pepper@potts.com"
+				],
+				"verified_recipients": [
+				  "James@Rhodes.com"
+				]
+			}`,
+			want: []RecipientStatus{
+				{Recipient: "james@rhodes.com", Verified: true},
+				{Recipient: "pepper@potts.com", Verified: false},
+			},
+		},
+		{
+			name: "verification pending",
+			req: request{
+				domain: "stark.com",
+				alias:  "tony",
+			},
+			res: `{
+				"name": "tony",
+				"has_recipient_verification": true,
+				"recipients": [
+				  "james@rhodes.com",
+				  "pepper@potts.com",
+				  "happy@hogan.com"
+				],
+				"verified_recipients": [
+				  "james@rhodes.com"
+				],
+				"pending_recipients": [
+				  "james@rhodes.com",
+				  "Pepper@Potts.com"
+				]
+			}`,
+			want: []RecipientStatus{
+				{Recipient: "james@rhodes.com", Verified: true},
+				{Recipient: "pepper@potts.com", Pending: true},
+				{Recipient: "happy@hogan.com"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, tt.res)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, _ := c.GetAliasRecipientStatus(tt.req.domain, tt.req.alias)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_EnableDisableAlias(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		want    string
+	}{
+		{
+			name:    "enable",
+			enabled: true,
+			want:    `{"is_enabled":true}`,
+		},
+		{
+			name:    "disable",
+			enabled: false,
+			want:    `{"is_enabled":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ = io.ReadAll(r.Body)
+
+				fmt.Fprintf(w, `{"name": "tony", "is_enabled": %t}`, tt.enabled)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			var got *Alias
+			if tt.enabled {
+				got, _ = c.EnableAlias("stark.com", "tony")
+			} else {
+				got, _ = c.DisableAlias("stark.com", "tony")
+			}
+
+			if diff := cmp.Diff(tt.want, string(body)); diff != "" {
+				t.Fatalf("request bodies are not the same %s", diff)
+			}
+			if diff := cmp.Diff(&Alias{Name: "tony", IsEnabled: tt.enabled}, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_UpdateAlias_SendsOnlySetFields(t *testing.T) {
+	var calls []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		calls = append(calls, r.Method+" "+string(b))
+
+		fmt.Fprintf(w, `{
+			"name": "tony",
+			"description": "main email",
+			"labels": ["catch-all"],
+			"is_enabled": true,
+			"error_code_if_disabled": 421,
+			"recipients": ["james@rhodes.com"]
+		}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	_, err := c.UpdateAlias("stark.com", "tony", AliasParameters{
+		Labels: pointSliceOfStrings([]string{"work"}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{`PUT {"labels":["work"]}`}
+	if diff := cmp.Diff(want, calls); diff != "" {
+		t.Fatalf("request bodies are not the same %s", diff)
+	}
+}
+
+func TestClient_UpdateAlias_ClearsFields(t *testing.T) {
+	var body string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			b, _ := io.ReadAll(r.Body)
+			body = string(b)
+		}
+
+		fmt.Fprintf(w, `{
+			"name": "tony",
+			"description": "main email",
+			"labels": ["catch-all"],
+			"is_enabled": true,
+			"recipients": ["james@rhodes.com"],
+			"vacation_responder": {"is_enabled": true, "end_date": "2023-12-31T00:00:00Z"}
+		}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	_, err := c.UpdateAlias("stark.com", "tony", AliasParameters{
+		Labels: pointSliceOfStrings([]string{}),
+		Clear:  []string{"description", "vacation_responder_end_date"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"description":null,"labels":[],"vacation_responder_end_date":null}`
+	if diff := cmp.Diff(want, body); diff != "" {
+		t.Fatalf("request bodies are not the same %s", diff)
+	}
+}
+
+func TestClient_GetAliasesFiltered(t *testing.T) {
+	tests := []struct {
+		name   string
+		params ListAliasParameters
+		want   string
+	}{
+		{
+			name: "no parameters",
+			want: "",
+		},
+		{
+			name: "everything at once",
+			params: ListAliasParameters{
+				ListOptions: ListOptions{
+					Search: "tony",
+				},
+				Label:     "catch-all",
+				IsEnabled: pointBool(true),
+				SortField: "created_at",
+				SortOrder: "desc",
+			},
+			want: "is_enabled=true&labels=catch-all&q=tony&sort=-created_at",
+		},
+		{
+			name: "ascending sort",
+			params: ListAliasParameters{
+				SortField: "name",
+				SortOrder: "asc",
+			},
+			want: "sort=name",
+		},
+		{
+			name: "name and recipient with pagination",
+			params: GetAliasesOptions{
+				ListOptions: ListOptions{
+					Page:  2,
+					Limit: 50,
+				},
+				Name:      "tony",
+				Recipient: "james@rhodes.com",
+			},
+			want: "limit=50&name=tony&page=2&recipient=james%40rhodes.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.URL.RawQuery
+
+				fmt.Fprintf(w, `[]`)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			_, _ = c.GetAliasesFiltered("stark.com", tt.params)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestAliasParameters_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		params AliasParameters
+		want   string
+	}{
+		{
+			name: "empty",
+			want: `{}`,
+		},
+		{
+			name: "everything at once",
+			params: AliasParameters{
+				Recipients:               pointSliceOfStrings([]string{"james@rhodes.com"}),
+				Description:              "main email",
+				Labels:                   pointSliceOfStrings([]string{"catch-all"}),
+				HasRecipientVerification: pointBool(false),
+				IsEnabled:                pointBool(true),
+			},
+			want: `{"recipients":["james@rhodes.com"],"description":"main email","labels":["catch-all"],"has_recipient_verification":false,"is_enabled":true}`,
+		},
+		{
+			name: "cleared lists",
+			params: AliasParameters{
+				Recipients: pointSliceOfStrings([]string{}),
+				Labels:     pointSliceOfStrings([]string{}),
+			},
+			want: `{"recipients":[],"labels":[]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, string(b)); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+
+			var got AliasParameters
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.params, got); diff != "" {
+				t.Fatalf("round trip values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_CreateAlias_Body(t *testing.T) {
+	tests := []struct {
+		name   string
+		params AliasParameters
+		want   string
+	}{
+		{
+			name: "name only",
+			want: `{"name":"tony"}`,
+		},
+		{
+			name: "imap and vacation responder",
+			params: AliasParameters{
+				Recipients:                 pointSliceOfStrings([]string{"james@rhodes.com"}),
+				HasIMAP:                    pointBool(true),
+				MaxQuota:                   pointInt64(1073741824),
+				ErrorCodeIfDisabled:        pointErrorCode(ErrorCodeHardReject),
+				VacationResponderIsEnabled: pointBool(true),
+				VacationResponderStartDate: pointTime(parseTime("2023-12-24T00:00:00Z")),
+				VacationResponderSubject:   pointString("Out of office"),
+			},
+			want: `{"name":"tony","recipients":["james@rhodes.com"],"error_code_if_disabled":550,"has_imap":true,"max_quota":1073741824,"vacation_responder_is_enabled":true,"vacation_responder_start_date":"2023-12-24T00:00:00Z","vacation_responder_subject":"Out of office"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var contentType, body string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				contentType = r.Header.Get("Content-Type")
+				b, _ := io.ReadAll(r.Body)
+				body = string(b)
+
+				fmt.Fprint(w, `{}`)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			_, _ = c.CreateAlias("stark.com", "tony", tt.params)
+			if diff := cmp.Diff("application/json", contentType); diff != "" {
+				t.Fatalf("content types are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, body); diff != "" {
+				t.Fatalf("request bodies are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func pointInt(i int) *int {
+	return &i
+}
+
+func pointErrorCode(c ErrorCode) *ErrorCode {
+	return &c
+}
+
+func pointInt64(i int64) *int64 {
+	return &i
+}
+
+func pointTime(t time.Time) *time.Time {
+	return &t
+}
+
+func TestClient_UpdateAlias_VacationResponder(t *testing.T) {
+	tests := []struct {
+		name   string
+		params AliasParameters
+		want   string
+	}{
+		{
+			name: "leaves the responder out",
+			params: AliasParameters{
+				Description: "on leave",
+			},
+			want: `{"description":"on leave"}`,
+		},
+		{
+			name: "turns the responder off",
+			params: AliasParameters{
+				VacationResponderIsEnabled: pointBool(false),
+			},
+			want: `{"vacation_responder_is_enabled":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPut {
+					b, _ := io.ReadAll(r.Body)
+					body = string(b)
+				}
+
+				fmt.Fprint(w, `{
+					"name": "tony",
+					"is_enabled": true,
+					"recipients": [],
+					"labels": [],
+					"vacation_responder": {
+					  "is_enabled": true,
+					  "start_date": "2023-12-24T00:00:00Z",
+					  "end_date": "2024-01-02T00:00:00Z",
+					  "subject": "Out of office",
+					  "message": "Back in January."
+					}
+				}`)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, err := c.UpdateAlias("stark.com", "tony", tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, body); diff != "" {
+				t.Fatalf("request bodies are not the same %s", diff)
+			}
+
+			want := VacationResponder{
+				IsEnabled: true,
+				StartDate: &Timestamp{parseTime("2023-12-24T00:00:00Z")},
+				EndDate:   &Timestamp{parseTime("2024-01-02T00:00:00Z")},
+				Subject:   "Out of office",
+				Message:   "Back in January.",
+			}
+			if diff := cmp.Diff(want, got.VacationResponder); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_GetAlias_IMAP(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"name": "tony",
+			"has_imap": true,
+			"has_pgp": true,
+			"public_key": "-----BEGIN PGP PUBLIC KEY BLOCK-----",
+			"max_quota": 10737418240,
+			"storage_used": 1073741824
+		}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	got, _ := c.GetAlias("stark.com", "tony")
+
+	want := &Alias{
+		Name:        "tony",
+		HasIMAP:     true,
+		HasPGP:      true,
+		PublicKey:   "-----BEGIN PGP PUBLIC KEY BLOCK-----",
+		MaxQuota:    10737418240,
+		StorageUsed: 1073741824,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestClient_UpsertAlias(t *testing.T) {
+	const alias = `{"name": "tony", "recipients": ["james@rhodes.com"], "is_enabled": true}`
+
+	tests := []struct {
+		name    string
+		handler func(calls []string) (int, string)
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "missing alias is created",
+			handler: func(calls []string) (int, string) {
+				if len(calls) == 1 {
+					return http.StatusNotFound, `{"message": "Alias does not exist"}`
+				}
+				return http.StatusOK, alias
+			},
+			want: []string{"GET /v1/domains/stark.com/aliases/tony", "POST /v1/domains/stark.com/aliases"},
+		},
+		{
+			name: "existing alias is updated",
+			handler: func(calls []string) (int, string) {
+				return http.StatusOK, alias
+			},
+			want: []string{"GET /v1/domains/stark.com/aliases/tony", "PUT /v1/domains/stark.com/aliases/tony"},
+		},
+		{
+			name: "alias created concurrently is updated",
+			handler: func(calls []string) (int, string) {
+				switch len(calls) {
+				case 1:
+					return http.StatusNotFound, `{"message": "Alias does not exist"}`
+				case 2:
+					return http.StatusBadRequest, `{"message": "Alias already exists for domain."}`
+				}
+				return http.StatusOK, alias
+			},
+			want: []string{
+				"GET /v1/domains/stark.com/aliases/tony",
+				"POST /v1/domains/stark.com/aliases",
+				"PUT /v1/domains/stark.com/aliases/tony",
+			},
+		},
+		{
+			name: "other create errors are returned",
+			handler: func(calls []string) (int, string) {
+				if len(calls) == 1 {
+					return http.StatusNotFound, `{"message": "Alias does not exist"}`
+				}
+				return http.StatusBadRequest, `{"message": "Invalid recipients."}`
+			},
+			want:    []string{"GET /v1/domains/stark.com/aliases/tony", "POST /v1/domains/stark.com/aliases"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+
+				code, body := tt.handler(calls)
+				w.WriteHeader(code)
+				fmt.Fprint(w, body)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, err := c.UpsertAlias("stark.com", "tony", AliasParameters{
+				Recipients: pointSliceOfStrings([]string{"james@rhodes.com"}),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Name != "tony" {
+				t.Fatalf("unexpected alias %+v", got)
+			}
+			if diff := cmp.Diff(tt.want, calls); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_CreateCatchAllAndRegexAlias(t *testing.T) {
+	var body map[string]any
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	if _, err := c.CreateCatchAllAlias("stark.com", AliasParameters{}); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("*", body["name"]); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	if _, err := c.CreateRegexAlias("stark.com", "/^support-.+$/i", AliasParameters{}); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("/^support-.+$/i", body["name"]); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	body = nil
+	if _, err := c.CreateRegexAlias("stark.com", "/^support-(/", AliasParameters{}); err == nil {
+		t.Fatal("expected an error for an invalid pattern")
+	}
+	if body != nil {
+		t.Fatal("invalid patterns should not be sent")
+	}
+}
+
+func TestValidateRegexAliasName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "/^support/"},
+		{name: "/^(sales|support)-.+$/gi"},
+		{name: "/a/b/"},
+		{name: "^support", wantErr: true},
+		{name: "/^support", wantErr: true},
+		{name: "//", wantErr: true},
+		{name: "/^support/x", wantErr: true},
+		{name: "/[a-/", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRegexAliasName(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_GetAlias_EscapesName(t *testing.T) {
+	tests := []struct {
+		alias string
+		want  string
+	}{
+		{alias: "*", want: "/v1/domains/stark.com/aliases/%2A"},
+		{alias: "/^support/", want: "/v1/domains/stark.com/aliases/%2F%5Esupport%2F"},
+		{alias: "foo/bar", want: "/v1/domains/stark.com/aliases/foo%2Fbar"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alias, func(t *testing.T) {
+			var got string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.URL.EscapedPath()
+
+				fmt.Fprint(w, `{}`)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			if _, err := c.GetAlias("stark.com", tt.alias); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_GetAliasByID(t *testing.T) {
+	var got string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Path
+
+		fmt.Fprint(w, `{"id": "609d8ec0a1b1c3e3e8f1a2b3", "name": "tony"}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	alias, err := c.GetAliasByID("stark.com", "609d8ec0a1b1c3e3e8f1a2b3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("/v1/domains/stark.com/aliases/609d8ec0a1b1c3e3e8f1a2b3", got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+	if alias.Name != "tony" {
+		t.Fatalf("unexpected alias %+v", alias)
+	}
+
+	got = ""
+	if _, err := c.GetAliasByID("stark.com", "tony.stark"); err == nil {
+		t.Fatal("expected an error for a name passed as an id")
+	}
+	if got != "" {
+		t.Fatal("invalid ids should not be sent")
+	}
+}
+
+func TestClient_GetAliasByName(t *testing.T) {
+	tests := []struct {
+		name         string
+		alias        string
+		res          string
+		want         string
+		wantNotFound bool
+	}{
+		{
+			name:  "exact match",
+			alias: "tony+work",
+			res:   `[{"name": "tony+work-old"}, {"name": "tony+work"}]`,
+			want:  "tony+work",
+		},
+		{
+			name:         "no match",
+			alias:        "tony.stark",
+			res:          `[{"name": "tony"}]`,
+			wantNotFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.Query().Get("name")
+
+				fmt.Fprint(w, tt.res)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			alias, err := c.GetAliasByName("stark.com", tt.alias)
+			if diff := cmp.Diff(tt.alias, query); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+			if tt.wantNotFound {
+				if !IsNotFound(err) {
+					t.Fatalf("expected a not found error, got %v", err)
+				}
+				want := fmt.Sprintf("status: 404, message: alias %q does not exist", tt.alias)
+				if diff := cmp.Diff(want, err.Error()); diff != "" {
+					t.Fatalf("values are not the same %s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, alias.Name); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_GenerateAliasPassword_HidesSecretsFromHooks(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"username": "tony@stark.com", "password": "hKJO_0vJSy!L0Bzm,Hj0"}`)
+	}))
+	defer svr.Close()
+
+	var requests, responses int
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	},
+		WithRequestHook(func(*http.Request) { requests++ }),
+		WithResponseHook(func(*http.Response) { responses++ }),
+		WithSecretsHiddenFromHooks(),
+	)
+
+	if _, err := c.GenerateAliasPassword("stark.com", "tony", GeneratePasswordParameters{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetAlias("stark.com", "tony"); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]int{1, 1}, []int{requests, responses}); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
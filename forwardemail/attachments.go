@@ -0,0 +1,147 @@
+package forwardemail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+)
+
+// sniffLength is the number of bytes http.DetectContentType looks at.
+const sniffLength = 512
+
+// NewAttachment returns an attachment whose content is read from r while the email is sent,
+// so large files are never held in memory. The content type is guessed from the file
+// extension, then from the first bytes of the content.
+func NewAttachment(filename string, r io.Reader) (Attachment, error) {
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return Attachment{}, err
+	}
+	head = head[:n]
+
+	contentType := mime.TypeByExtension(filepath.Ext(filename))
+	if contentType == "" {
+		contentType = http.DetectContentType(head)
+	}
+
+	return Attachment{
+		Filename:    filename,
+		ContentType: contentType,
+		Encoding:    "base64",
+		Reader:      io.MultiReader(bytes.NewReader(head), r),
+	}, nil
+}
+
+// NewInlineAttachment is like NewAttachment but for content referenced from the HTML body
+// with a "cid:" URL, e.g. <img src="cid:logo">.
+func NewInlineAttachment(filename string, cid string, r io.Reader) (Attachment, error) {
+	attachment, err := NewAttachment(filename, r)
+	attachment.Cid = cid
+
+	return attachment, err
+}
+
+func (p EmailParameters) hasStreamingAttachments() bool {
+	for _, attachment := range p.Attachments {
+		if attachment.Reader != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// streamEmailBody encodes parameters as JSON, base64 encoding the content of the streaming
+// attachments while the request body is being read. The encoding stops when the body is
+// closed or ctx is done.
+func streamEmailBody(ctx context.Context, parameters EmailParameters) io.ReadCloser {
+	pr, pw := io.Pipe()
+	stop := context.AfterFunc(ctx, func() {
+		pw.CloseWithError(ctx.Err())
+	})
+
+	go func() {
+		defer stop()
+		pw.CloseWithError(writeEmailBody(pw, parameters))
+	}()
+
+	return pr
+}
+
+func writeEmailBody(w io.Writer, parameters EmailParameters) error {
+	attachments := parameters.Attachments
+	parameters.Attachments = nil
+
+	if err := writeObjectStart(w, parameters); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, `"attachments":[`); err != nil {
+		return err
+	}
+
+	for i, attachment := range attachments {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+
+		if attachment.Reader == nil {
+			b, err := json.Marshal(attachment)
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(b); err != nil {
+				return err
+			}
+			continue
+		}
+
+		attachment.Encoding = "base64"
+		if err := writeObjectStart(w, attachment); err != nil {
+			return err
+		}
+
+		// Base64 output never needs escaping inside a JSON string.
+		if _, err := io.WriteString(w, `"content":"`); err != nil {
+			return err
+		}
+		encoder := base64.NewEncoder(base64.StdEncoding, w)
+		if _, err := io.Copy(encoder, attachment.Reader); err != nil {
+			return err
+		}
+		if err := encoder.Close(); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, `"}`); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, "]}")
+
+	return err
+}
+
+// writeObjectStart writes v as a JSON object left open for more fields.
+func writeObjectStart(w io.Writer, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	b = b[:len(b)-1]
+	if len(b) > 1 {
+		b = append(b, ',')
+	}
+
+	_, err = w.Write(b)
+
+	return err
+}
@@ -0,0 +1,179 @@
+package forwardemail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewAttachment(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		want     string
+	}{
+		{
+			name:     "from extension",
+			filename: "report.csv",
+			content:  "a,b\n1,2\n",
+			want:     "text/csv; charset=utf-8",
+		},
+		{
+			name:     "from content",
+			filename: "report",
+			content:  "%PDF-1.7\n",
+			want:     "application/pdf",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachment, err := NewAttachment(tt.filename, strings.NewReader(tt.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, attachment.ContentType); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+
+			content, err := io.ReadAll(attachment.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.content, string(content)); diff != "" {
+				t.Fatalf("sniffed bytes were lost %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_CreateEmail_StreamingAttachments(t *testing.T) {
+	// Large enough to span several reads of the request body.
+	large := bytes.Repeat([]byte("jarvis"), 200_000)
+
+	var got EmailParameters
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("request body is not valid JSON: %v", err)
+		}
+
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	logo, err := NewInlineAttachment("logo.png", "logo", strings.NewReader("\x89PNG\r\n\x1a\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := NewAttachment("data.bin", bytes.NewReader(large))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.CreateEmail(EmailParameters{
+		From: "tony@stark.com",
+		To:   []string{"pepper@stark.com"},
+		Html: `<img src="cid:logo">`,
+		Attachments: []Attachment{
+			{Filename: "notes.txt", Content: "hello"},
+			logo,
+			data,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := EmailParameters{
+		From: "tony@stark.com",
+		To:   []string{"pepper@stark.com"},
+		Html: `<img src="cid:logo">`,
+		Attachments: []Attachment{
+			{Filename: "notes.txt", Content: "hello"},
+			{Filename: "logo.png", Content: base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n\x1a\n")), Encoding: "base64", ContentType: "image/png", Cid: "logo"},
+			{Filename: "data.bin", Content: base64.StdEncoding.EncodeToString(large), Encoding: "base64", ContentType: "application/octet-stream"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func Test_writeEmailBody_MinimalFields(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeEmailBody(&buf, EmailParameters{
+		Attachments: []Attachment{{Reader: strings.NewReader("hi")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"from":"","attachments":[{"encoding":"base64","content":"aGk="}]}`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func Test_streamEmailBody_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	body := streamEmailBody(ctx, EmailParameters{
+		From:        "tony@stark.com",
+		Attachments: []Attachment{{Filename: "data.bin", Reader: strings.NewReader("jarvis")}},
+	})
+	defer body.Close()
+
+	cancel()
+	if _, err := io.ReadAll(body); !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+// closeRecorder is a request body recording whether it was closed.
+type closeRecorder struct {
+	io.Reader
+	closed atomic.Bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed.Store(true)
+	return nil
+}
+
+func TestClient_send_ClosesBodyWhenNotSent(t *testing.T) {
+	c := NewClient(ClientOptions{
+		ApiUrl: "http://127.0.0.1:0",
+	}, WithRateLimit(0.001, 1))
+	c.limiter.reserve(c.clock.Now())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	body := &closeRecorder{Reader: strings.NewReader("{}")}
+	req, err := c.NewRequestWithContext(ctx, "POST", "/v1/emails", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.send(req); !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !body.closed.Load() {
+		t.Errorf("expected the request body to be closed")
+	}
+}
@@ -0,0 +1,81 @@
+package forwardemail
+
+import "net/http"
+
+// Authenticator authenticates the requests sent to the API, typically by setting their
+// Authorization header. Authenticate is called as every request is built, so an
+// implementation can refresh its credentials, and must be safe for concurrent use. By
+// default the client authenticates with the API key as the basic auth username.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// AuthenticatorFunc adapts a function to an Authenticator.
+type AuthenticatorFunc func(req *http.Request) error
+
+func (f AuthenticatorFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// BasicAuth authenticates with a username and password, such as those of an alias.
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+func (a BasicAuth) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(a.Username, a.Password)
+
+	return nil
+}
+
+// BearerToken authenticates with an "Authorization: Bearer" header, for the API tokens
+// accepted by proxies in front of self-hosted instances. Wrap a token source in an
+// AuthenticatorFunc to refresh the token.
+type BearerToken string
+
+func (t BearerToken) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+string(t))
+
+	return nil
+}
+
+// WithAuthenticator authenticates the requests with auth instead of the API key. The
+// mailbox methods keep using the alias credentials, see WithAliasAuthenticator.
+func WithAuthenticator(auth Authenticator) Option {
+	return func(c *Client) {
+		c.auth = auth
+	}
+}
+
+// WithAliasAuthenticator authenticates the requests of the mailbox methods with auth, like
+// WithAliasCredentials does with the username and password of an alias.
+func WithAliasAuthenticator(auth Authenticator) Option {
+	return func(c *Client) {
+		c.aliasAuth = auth
+	}
+}
+
+// apiKeyAuth is the default Authenticator, sending the API key as the basic auth username.
+type apiKeyAuth struct {
+	c *Client
+}
+
+func (a apiKeyAuth) Authenticate(req *http.Request) error {
+	key, err := a.c.apiKey()
+	if err != nil {
+		return err
+	}
+
+	req.SetBasicAuth(key, "")
+
+	return nil
+}
+
+func (c *Client) authenticator() Authenticator {
+	if c.auth != nil {
+		return c.auth
+	}
+
+	return apiKeyAuth{c: c}
+}
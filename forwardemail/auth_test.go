@@ -0,0 +1,76 @@
+package forwardemail
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithAuthenticator(t *testing.T) {
+	errNoToken := errors.New("no token")
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "api key",
+			want: []string{"Basic YXBpX2tleTo=", "Basic dG9ueUBzdGFyay5jb206c2VjcmV0"},
+		},
+		{
+			name: "bearer token",
+			opts: []Option{WithAuthenticator(BearerToken("token"))},
+			want: []string{"Bearer token", "Basic dG9ueUBzdGFyay5jb206c2VjcmV0"},
+		},
+		{
+			name: "alias token",
+			opts: []Option{WithAliasAuthenticator(BearerToken("alias-token"))},
+			want: []string{"Basic YXBpX2tleTo=", "Bearer alias-token"},
+		},
+		{
+			name: "failing authenticator",
+			opts: []Option{WithAuthenticator(AuthenticatorFunc(func(*http.Request) error {
+				return errNoToken
+			}))},
+			wantErr: errNoToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = append(got, r.Header.Get("Authorization"))
+				fmt.Fprint(w, `[]`)
+			}))
+			defer svr.Close()
+
+			opts := append([]Option{WithAliasCredentials("tony@stark.com", "secret")}, tt.opts...)
+			c := NewClient(ClientOptions{
+				ApiKey: "api_key",
+				ApiUrl: svr.URL,
+			}, opts...)
+
+			_, err := c.GetDomains()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if _, err := c.GetFolders(); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
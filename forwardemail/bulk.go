@@ -0,0 +1,156 @@
+package forwardemail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BulkOptions controls how bulk operations fan out requests.
+type BulkOptions struct {
+	// Concurrency is the number of requests in flight at once. Defaults to 4.
+	Concurrency int
+	// RequestsPerSecond caps the request rate of the operation. Zero means no cap. To cap
+	// every request of the client instead, see WithRateLimit.
+	RequestsPerSecond float64
+}
+
+type BulkAlias struct {
+	Name       string
+	Parameters AliasParameters
+}
+
+// BulkResult is the outcome of one item of a bulk operation. Alias is nil for deletes and failures.
+type BulkResult struct {
+	Name  string
+	Alias *Alias
+	Err   error
+}
+
+// BulkResults are returned in the same order as the input items.
+type BulkResults []BulkResult
+
+// Failed returns the results that ended in an error.
+func (r BulkResults) Failed() BulkResults {
+	var failed BulkResults
+	for _, result := range r {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+
+	return failed
+}
+
+// Err joins the errors of every failed item, or returns nil when all of them succeeded.
+func (r BulkResults) Err() error {
+	var errs []error
+	for _, result := range r.Failed() {
+		errs = append(errs, fmt.Errorf("%s: %w", result.Name, result.Err))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Client) BulkCreateAliases(domain string, aliases []BulkAlias, options BulkOptions, opts ...RequestOption) BulkResults {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.BulkCreateAliasesContext(ctx, domain, aliases, options)
+}
+
+func (c *Client) BulkCreateAliasesContext(ctx context.Context, domain string, aliases []BulkAlias, options BulkOptions) BulkResults {
+	return runBulk(ctx, options, bulkAliasNames(aliases), func(i int) BulkResult {
+		item, err := c.CreateAliasContext(ctx, domain, aliases[i].Name, aliases[i].Parameters)
+		return BulkResult{Name: aliases[i].Name, Alias: item, Err: err}
+	})
+}
+
+func (c *Client) BulkUpdateAliases(domain string, aliases []BulkAlias, options BulkOptions, opts ...RequestOption) BulkResults {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.BulkUpdateAliasesContext(ctx, domain, aliases, options)
+}
+
+func (c *Client) BulkUpdateAliasesContext(ctx context.Context, domain string, aliases []BulkAlias, options BulkOptions) BulkResults {
+	return runBulk(ctx, options, bulkAliasNames(aliases), func(i int) BulkResult {
+		item, err := c.UpdateAliasContext(ctx, domain, aliases[i].Name, aliases[i].Parameters)
+		return BulkResult{Name: aliases[i].Name, Alias: item, Err: err}
+	})
+}
+
+func (c *Client) BulkDeleteAliases(domain string, aliases []string, options BulkOptions, opts ...RequestOption) BulkResults {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.BulkDeleteAliasesContext(ctx, domain, aliases, options)
+}
+
+func (c *Client) BulkDeleteAliasesContext(ctx context.Context, domain string, aliases []string, options BulkOptions) BulkResults {
+	return runBulk(ctx, options, aliases, func(i int) BulkResult {
+		err := c.DeleteAliasContext(ctx, domain, aliases[i])
+		return BulkResult{Name: aliases[i], Err: err}
+	})
+}
+
+func bulkAliasNames(aliases []BulkAlias) []string {
+	names := make([]string, len(aliases))
+	for i, alias := range aliases {
+		names[i] = alias.Name
+	}
+
+	return names
+}
+
+func runBulk(ctx context.Context, options BulkOptions, names []string, do func(i int) BulkResult) BulkResults {
+	concurrency := options.Concurrency
+	if concurrency < 1 {
+		concurrency = 4
+	}
+
+	var ticks <-chan time.Time
+	if options.RequestsPerSecond > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / options.RequestsPerSecond))
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	results := make(BulkResults, len(names))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = do(i)
+			}
+		}()
+	}
+
+	for i := range names {
+		if ticks != nil && i > 0 {
+			select {
+			case <-ctx.Done():
+			case <-ticks:
+			}
+		}
+
+		// Items that were never sent because the context ended still get a result.
+		if err := ctx.Err(); err != nil {
+			results[i] = BulkResult{Name: names[i], Err: err}
+			continue
+		}
+
+		indexes <- i
+	}
+	close(indexes)
+
+	wg.Wait()
+
+	return results
+}
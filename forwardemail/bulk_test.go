@@ -0,0 +1,123 @@
+package forwardemail
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_BulkCreateAliases(t *testing.T) {
+	var inFlight, maxInFlight int32
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		var body struct {
+			Name string `json:"name"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		if body.Name == "taken" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message": "Alias already exists."}`)
+			return
+		}
+
+		fmt.Fprintf(w, `{"name": %q}`, body.Name)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	aliases := []BulkAlias{{Name: "tony"}, {Name: "taken"}, {Name: "pepper"}, {Name: "happy"}, {Name: "rhodey"}}
+
+	got := c.BulkCreateAliases("stark.com", aliases, BulkOptions{Concurrency: 2})
+
+	var names []string
+	for _, result := range got {
+		names = append(names, result.Name)
+		if result.Name != "taken" && (result.Err != nil || result.Alias == nil || result.Alias.Name != result.Name) {
+			t.Fatalf("unexpected result %+v", result)
+		}
+	}
+	if diff := cmp.Diff([]string{"tony", "taken", "pepper", "happy", "rhodey"}, names); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	failed := got.Failed()
+	if len(failed) != 1 || failed[0].Name != "taken" || !strings.Contains(got.Err().Error(), "taken: status: 400") {
+		t.Fatalf("unexpected failures %+v", failed)
+	}
+
+	if maxInFlight > 2 {
+		t.Fatalf("expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
+
+func TestClient_BulkDeleteAliases(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	start := time.Now()
+	got := c.BulkDeleteAliases("stark.com", []string{"tony", "pepper", "happy"}, BulkOptions{RequestsPerSecond: 50})
+
+	if err := got.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 3 {
+		t.Fatalf("expected 3 requests, got %v", paths)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("expected requests to be spread out, took %s", elapsed)
+	}
+}
+
+func TestClient_BulkUpdateAliases_Canceled(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	got := c.BulkUpdateAliasesContext(ctx, "stark.com", []BulkAlias{{Name: "tony"}, {Name: "pepper"}}, BulkOptions{})
+	if diff := cmp.Diff(2, len(got.Failed())); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+	if diff := cmp.Diff("pepper", got[1].Name); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
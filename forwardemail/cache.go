@@ -0,0 +1,134 @@
+package forwardemail
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a successful GET response stored in a Cache.
+type CacheEntry struct {
+	Body    []byte
+	Header  http.Header
+	ETag    string
+	Expires time.Time
+}
+
+// Cache stores GET responses for a client configured with WithCache.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry)
+	// Clear drops every entry. It is called after every successful request
+	// that modifies data, since any of them can change the cached responses.
+	Clear()
+}
+
+// WithCache serves GET requests from cache for ttl after they were fetched. Once an entry
+// is stale it is revalidated with If-None-Match when the API returned an ETag, and
+// refreshed without downloading the body again if the API answers 304 Not Modified.
+// Entries are keyed by URL and API key, and the whole cache is cleared whenever a request
+// other than GET succeeds.
+func WithCache(cache Cache, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheTTL = ttl
+	}
+}
+
+// MemoryCache is an in-memory Cache.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]*CacheEntry
+}
+
+// NewMemoryCache returns an empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: map[string]*CacheEntry{}}
+}
+
+func (m *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+
+	return entry, ok
+}
+
+func (m *MemoryCache) Set(key string, entry *CacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[key] = entry
+}
+
+func (m *MemoryCache) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = map[string]*CacheEntry{}
+}
+
+func (c *Client) doCachedRequest(req *http.Request) ([]byte, http.Header, error) {
+	if req.Method != http.MethodGet {
+		res, body, err := c.readResponse(req)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if res.StatusCode == http.StatusOK || res.StatusCode == http.StatusNoContent {
+			if !c.isDryRun(req) {
+				c.cache.Clear()
+			}
+			return body, res.Header, nil
+		}
+
+		return nil, nil, c.newAPIError(res, body)
+	}
+
+	key := cacheKey(req)
+	entry, cached := c.cache.Get(key)
+	if cached && c.clock.Now().Before(entry.Expires) {
+		return entry.Body, entry.Header.Clone(), nil
+	}
+	if cached && entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	res, body, err := c.readResponse(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case res.StatusCode == http.StatusNotModified && cached:
+		refreshed := *entry
+		refreshed.Expires = c.clock.Now().Add(c.cacheTTL)
+		c.cache.Set(key, &refreshed)
+
+		return refreshed.Body, refreshed.Header.Clone(), nil
+	case res.StatusCode == http.StatusOK:
+		c.cache.Set(key, &CacheEntry{
+			Body:    body,
+			Header:  res.Header.Clone(),
+			ETag:    res.Header.Get("ETag"),
+			Expires: c.clock.Now().Add(c.cacheTTL),
+		})
+
+		return body, res.Header, nil
+	case res.StatusCode == http.StatusNoContent:
+		return body, res.Header, nil
+	}
+
+	return nil, nil, c.newAPIError(res, body)
+}
+
+// cacheKey keeps the responses seen with different API keys apart without storing the keys.
+func cacheKey(req *http.Request) string {
+	auth := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+
+	return hex.EncodeToString(auth[:8]) + " " + req.URL.String()
+}
@@ -0,0 +1,122 @@
+package forwardemail
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_WithCache(t *testing.T) {
+	var requests []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.Header.Get("If-None-Match"))
+
+		if r.Method != http.MethodGet {
+			fmt.Fprint(w, `{"name": "stark.com"}`)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"name": "stark.com"}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	}, WithCache(NewMemoryCache(), time.Hour))
+
+	for i := 0; i < 2; i++ {
+		domain, err := c.GetDomain("stark.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if domain.Name != "stark.com" {
+			t.Fatalf("unexpected domain %+v", domain)
+		}
+	}
+
+	if diff := cmp.Diff([]string{"GET "}, requests); diff != "" {
+		t.Fatalf("fresh entries should be served from cache %s", diff)
+	}
+
+	if _, err := c.UpdateDomain("stark.com", DomainParameters{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetDomain("stark.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"GET ", "PUT ", "GET "}
+	if diff := cmp.Diff(want, requests); diff != "" {
+		t.Fatalf("writes should clear the cache %s", diff)
+	}
+}
+
+func TestClient_WithCache_Revalidates(t *testing.T) {
+	var requests []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Header.Get("If-None-Match"))
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"name": "stark.com"}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	}, WithCache(NewMemoryCache(), 0))
+
+	for i := 0; i < 2; i++ {
+		domain, err := c.GetDomain("stark.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if domain.Name != "stark.com" {
+			t.Fatalf("unexpected domain %+v", domain)
+		}
+	}
+
+	if diff := cmp.Diff([]string{"", `"v1"`}, requests); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestClient_WithCache_SeparatesAPIKeys(t *testing.T) {
+	calls := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"email": "tony@stark.com"}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiKey: "tony",
+		ApiUrl: svr.URL,
+	}, WithCache(NewMemoryCache(), time.Hour))
+
+	if _, err := c.GetAccount(); err != nil {
+		t.Fatal(err)
+	}
+
+	c.SetAPIKey("pepper")
+	if _, err := c.GetAccount(); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+}
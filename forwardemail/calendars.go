@@ -0,0 +1,200 @@
+package forwardemail
+
+import (
+	"context"
+	"net/url"
+)
+
+// Calendar is a CalDAV calendar of an alias mailbox.
+type Calendar struct {
+	Id          string    `json:"id"`
+	Object      string    `json:"object"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Color       string    `json:"color"`
+	Timezone    string    `json:"timezone"`
+	CreatedAt   Timestamp `json:"created_at"`
+	UpdatedAt   Timestamp `json:"updated_at"`
+}
+
+// CalendarParameters are sent as a JSON body.
+type CalendarParameters struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Color       string `json:"color,omitempty"`
+	Timezone    string `json:"timezone,omitempty"`
+}
+
+// CalendarEvent is an event of a calendar. Ical is the iCalendar (RFC 5545) content of the event.
+type CalendarEvent struct {
+	Id        string    `json:"id"`
+	Object    string    `json:"object"`
+	Calendar  string    `json:"calendar_id"`
+	EventId   string    `json:"event_id"`
+	Ical      string    `json:"ical"`
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+}
+
+// CalendarEventParameters are sent as a JSON body.
+type CalendarEventParameters struct {
+	Calendar string `json:"calendar_id,omitempty"`
+	Ical     string `json:"ical"`
+}
+
+// GetCalendars returns the calendars of the mailbox of the alias credentials.
+func (c *Client) GetCalendars(opts ...RequestOption) ([]Calendar, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetCalendarsContext(ctx)
+}
+
+func (c *Client) GetCalendarsContext(ctx context.Context) ([]Calendar, error) {
+	var items []Calendar
+	if err := c.doMailboxJSON(ctx, "GET", "/v1/calendars", nil, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (c *Client) GetCalendar(id string, opts ...RequestOption) (*Calendar, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetCalendarContext(ctx, id)
+}
+
+func (c *Client) GetCalendarContext(ctx context.Context, id string) (*Calendar, error) {
+	var item Calendar
+	if err := c.doMailboxJSON(ctx, "GET", pathf("/v1/calendars/%s", id), nil, &item); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) CreateCalendar(parameters CalendarParameters, opts ...RequestOption) (*Calendar, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.CreateCalendarContext(ctx, parameters)
+}
+
+func (c *Client) CreateCalendarContext(ctx context.Context, parameters CalendarParameters) (*Calendar, error) {
+	var item Calendar
+	if err := c.doMailboxJSON(ctx, "POST", "/v1/calendars", parameters, &item); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) UpdateCalendar(id string, parameters CalendarParameters, opts ...RequestOption) (*Calendar, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.UpdateCalendarContext(ctx, id, parameters)
+}
+
+func (c *Client) UpdateCalendarContext(ctx context.Context, id string, parameters CalendarParameters) (*Calendar, error) {
+	var item Calendar
+	if err := c.doMailboxJSON(ctx, "PUT", pathf("/v1/calendars/%s", id), parameters, &item); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) DeleteCalendar(id string, opts ...RequestOption) error {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.DeleteCalendarContext(ctx, id)
+}
+
+func (c *Client) DeleteCalendarContext(ctx context.Context, id string) error {
+	return c.doMailboxJSON(ctx, "DELETE", pathf("/v1/calendars/%s", id), nil, nil)
+}
+
+// GetCalendarEvents returns the events of a calendar, or of every calendar when calendar is empty.
+func (c *Client) GetCalendarEvents(calendar string, opts ...RequestOption) ([]CalendarEvent, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetCalendarEventsContext(ctx, calendar)
+}
+
+func (c *Client) GetCalendarEventsContext(ctx context.Context, calendar string) ([]CalendarEvent, error) {
+	path := "/v1/calendar-events"
+	if calendar != "" {
+		path += "?" + url.Values{"calendar_id": {calendar}}.Encode()
+	}
+
+	var items []CalendarEvent
+	if err := c.doMailboxJSON(ctx, "GET", path, nil, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (c *Client) GetCalendarEvent(id string, opts ...RequestOption) (*CalendarEvent, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetCalendarEventContext(ctx, id)
+}
+
+func (c *Client) GetCalendarEventContext(ctx context.Context, id string) (*CalendarEvent, error) {
+	var item CalendarEvent
+	if err := c.doMailboxJSON(ctx, "GET", pathf("/v1/calendar-events/%s", id), nil, &item); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) CreateCalendarEvent(parameters CalendarEventParameters, opts ...RequestOption) (*CalendarEvent, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.CreateCalendarEventContext(ctx, parameters)
+}
+
+func (c *Client) CreateCalendarEventContext(ctx context.Context, parameters CalendarEventParameters) (*CalendarEvent, error) {
+	var item CalendarEvent
+	if err := c.doMailboxJSON(ctx, "POST", "/v1/calendar-events", parameters, &item); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) UpdateCalendarEvent(id string, parameters CalendarEventParameters, opts ...RequestOption) (*CalendarEvent, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.UpdateCalendarEventContext(ctx, id, parameters)
+}
+
+func (c *Client) UpdateCalendarEventContext(ctx context.Context, id string, parameters CalendarEventParameters) (*CalendarEvent, error) {
+	var item CalendarEvent
+	if err := c.doMailboxJSON(ctx, "PUT", pathf("/v1/calendar-events/%s", id), parameters, &item); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) DeleteCalendarEvent(id string, opts ...RequestOption) error {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.DeleteCalendarEventContext(ctx, id)
+}
+
+func (c *Client) DeleteCalendarEventContext(ctx context.Context, id string) error {
+	return c.doMailboxJSON(ctx, "DELETE", pathf("/v1/calendar-events/%s", id), nil, nil)
+}
@@ -0,0 +1,80 @@
+package forwardemail
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_Calendars(t *testing.T) {
+	var calls []string
+	svr := newMailboxServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, r.Method+" "+r.URL.RequestURI()+" "+string(body))
+
+		switch r.URL.Path {
+		case "/v1/calendars":
+			if r.Method == "GET" {
+				fmt.Fprint(w, `[{"id": "cal1", "name": "Work", "timezone": "America/New_York"}]`)
+				return
+			}
+			fmt.Fprint(w, `{"id": "cal1", "name": "Work"}`)
+		case "/v1/calendar-events":
+			if r.Method == "GET" {
+				fmt.Fprint(w, `[{"id": "e1", "calendar_id": "cal1", "ical": "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"}]`)
+				return
+			}
+			fmt.Fprint(w, `{"id": "e1", "calendar_id": "cal1"}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	})
+
+	c := NewClient(ClientOptions{ApiUrl: svr.URL}, WithAliasCredentials("tony@stark.com", "secret"))
+
+	calendars, err := c.GetCalendars()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]Calendar{{Id: "cal1", Name: "Work", Timezone: "America/New_York"}}, calendars); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+
+	if _, err := c.CreateCalendar(CalendarParameters{Name: "Work"}); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := c.GetCalendarEvents("cal1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []CalendarEvent{{Id: "e1", Calendar: "cal1", Ical: "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"}}
+	if diff := cmp.Diff(want, events); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+
+	if _, err := c.CreateCalendarEvent(CalendarEventParameters{Calendar: "cal1", Ical: "BEGIN:VCALENDAR"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteCalendarEvent("e1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteCalendar("cal1"); err != nil {
+		t.Fatal(err)
+	}
+
+	wantCalls := []string{
+		"GET /v1/calendars ",
+		`POST /v1/calendars {"name":"Work"}`,
+		"GET /v1/calendar-events?calendar_id=cal1 ",
+		`POST /v1/calendar-events {"calendar_id":"cal1","ical":"BEGIN:VCALENDAR"}`,
+		"DELETE /v1/calendar-events/e1 ",
+		"DELETE /v1/calendars/cal1 ",
+	}
+	if diff := cmp.Diff(wantCalls, calls); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+}
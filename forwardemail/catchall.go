@@ -0,0 +1,280 @@
+package forwardemail
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+)
+
+// CatchAllPassword is an IMAP/SMTP password that works for every alias of a domain.
+// Username and Password are only returned when the password is created.
+type CatchAllPassword struct {
+	Id          string    `json:"id"`
+	Description string    `json:"description"`
+	Username    string    `json:"username"`
+	Password    string    `json:"password"`
+	CreatedAt   Timestamp `json:"created_at"`
+}
+
+type CatchAllPasswordParameters struct {
+	NewPassword *string `form:"new_password"`
+	Description *string `form:"description"`
+}
+
+func (c *Client) GetCatchAllPasswords(domain string, opts ...RequestOption) ([]CatchAllPassword, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetCatchAllPasswordsContext(ctx, domain)
+}
+
+func (c *Client) GetCatchAllPasswordsContext(ctx context.Context, domain string) ([]CatchAllPassword, error) {
+	req, err := c.newRequest(ctx, "GET", pathf("/v1/domains/%s/catch-all-passwords", domain))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []CatchAllPassword
+
+	err = c.unmarshal(req, res, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// CreateCatchAllPassword generates a catch-all password, or sets NewPassword when given.
+func (c *Client) CreateCatchAllPassword(domain string, parameters CatchAllPasswordParameters, opts ...RequestOption) (*CatchAllPassword, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.CreateCatchAllPasswordContext(ctx, domain, parameters)
+}
+
+func (c *Client) CreateCatchAllPasswordContext(ctx context.Context, domain string, parameters CatchAllPasswordParameters) (*CatchAllPassword, error) {
+	params, err := encodeForm(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, "POST", pathf("/v1/domains/%s/catch-all-passwords", domain))
+	if err != nil {
+		return nil, err
+	}
+
+	setFormBody(req, params)
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var item CatchAllPassword
+
+	err = c.unmarshal(req, res, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) DeleteCatchAllPassword(domain string, id string, opts ...RequestOption) error {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.DeleteCatchAllPasswordContext(ctx, domain, id)
+}
+
+func (c *Client) DeleteCatchAllPasswordContext(ctx context.Context, domain string, id string) error {
+	req, err := c.newRequest(ctx, "DELETE", pathf("/v1/domains/%s/catch-all-passwords/%s", domain, id))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Catch-all mechanisms, for CatchAll.Mechanism.
+const (
+	// CatchAllMechanismAlias is the "*" alias of the domain, used by the paid plans.
+	CatchAllMechanismAlias = "alias"
+	// CatchAllMechanismDNS is the forward-email TXT record of the domain, used by the free
+	// plan, whose aliases are configured in DNS rather than through the API.
+	CatchAllMechanismDNS = "dns"
+)
+
+// forwardEmailRecordPrefix starts the TXT records configuring the aliases of a free domain.
+const forwardEmailRecordPrefix = "forward-email="
+
+// CatchAll is where the mail sent to the addresses of a domain without a more specific
+// alias goes, and how it is configured.
+type CatchAll struct {
+	// Mechanism is CatchAllMechanismAlias or CatchAllMechanismDNS.
+	Mechanism  string
+	Recipients []string
+	// Alias is the "*" alias for CatchAllMechanismAlias, nil when the domain has none.
+	Alias *Alias
+	// Record is the forward-email TXT record for CatchAllMechanismDNS. The API can't change
+	// the DNS of a domain, so SetCatchAll only returns the record to publish in its place.
+	Record *DNSRecord
+}
+
+// TXTResolver looks up the TXT records of a domain name, as *net.Resolver does.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// WithTXTResolver makes GetCatchAll and SetCatchAll read the DNS configuration of free
+// domains with resolver instead of net.DefaultResolver.
+func WithTXTResolver(resolver TXTResolver) Option {
+	return func(c *Client) {
+		c.resolver = resolver
+	}
+}
+
+// GetCatchAll returns the catch-all recipients of a domain. On the free plan they are read
+// from the forward-email TXT records of the domain, on the other plans from its "*" alias.
+func (c *Client) GetCatchAll(domain string, opts ...RequestOption) (*CatchAll, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetCatchAllContext(ctx, domain)
+}
+
+func (c *Client) GetCatchAllContext(ctx context.Context, domain string) (*CatchAll, error) {
+	item, err := c.GetDomainContext(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Plan == PlanFree {
+		entries, err := c.lookupForwardEmailEntries(ctx, item.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		catchAll, _ := splitCatchAllEntries(entries)
+		return &CatchAll{Mechanism: CatchAllMechanismDNS, Recipients: catchAll, Record: forwardEmailRecord(item.Name, entries)}, nil
+	}
+
+	alias, err := c.GetAliasContext(ctx, domain, CatchAllAliasName)
+	if IsNotFound(err) {
+		return &CatchAll{Mechanism: CatchAllMechanismAlias}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &CatchAll{Mechanism: CatchAllMechanismAlias, Recipients: alias.Recipients, Alias: alias}, nil
+}
+
+// SetCatchAll makes recipients the catch-all recipients of a domain, using the mechanism
+// of its plan. On the paid plans the "*" alias is created or updated, or deleted when there
+// are no recipients. On the free plan nothing is changed: the returned CatchAll.Record is
+// the forward-email TXT record to publish, keeping the specific aliases of the current one.
+func (c *Client) SetCatchAll(domain string, recipients []string, opts ...RequestOption) (*CatchAll, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.SetCatchAllContext(ctx, domain, recipients)
+}
+
+func (c *Client) SetCatchAllContext(ctx context.Context, domain string, recipients []string) (*CatchAll, error) {
+	item, err := c.GetDomainContext(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Plan == PlanFree {
+		entries, err := c.lookupForwardEmailEntries(ctx, item.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		_, aliases := splitCatchAllEntries(entries)
+		entries = append(aliases, recipients...)
+		return &CatchAll{Mechanism: CatchAllMechanismDNS, Recipients: recipients, Record: forwardEmailRecord(item.Name, entries)}, nil
+	}
+
+	if len(recipients) == 0 {
+		err := c.DeleteAliasContext(ctx, domain, CatchAllAliasName)
+		if err != nil && !IsNotFound(err) {
+			return nil, err
+		}
+
+		return &CatchAll{Mechanism: CatchAllMechanismAlias}, nil
+	}
+
+	alias, err := c.UpsertAliasContext(ctx, domain, CatchAllAliasName, AliasParameters{Recipients: &recipients})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CatchAll{Mechanism: CatchAllMechanismAlias, Recipients: alias.Recipients, Alias: alias}, nil
+}
+
+// lookupForwardEmailEntries returns the comma-separated entries of the forward-email TXT
+// records of a domain, none when it has no TXT records.
+func (c *Client) lookupForwardEmailEntries(ctx context.Context, domain string) ([]string, error) {
+	records, err := c.resolver.LookupTXT(ctx, domain)
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []string
+	for _, record := range records {
+		value, ok := strings.CutPrefix(record, forwardEmailRecordPrefix)
+		if !ok {
+			continue
+		}
+		for _, entry := range strings.Split(value, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// splitCatchAllEntries separates the recipients of every address, the entries with no alias
+// name such as "tony@stark.com" or a webhook URL, from the specific aliases, such as
+// "support:tony@stark.com" or "!old".
+func splitCatchAllEntries(entries []string) (catchAll []string, aliases []string) {
+	for _, entry := range entries {
+		isURL := strings.HasPrefix(entry, "http://") || strings.HasPrefix(entry, "https://")
+		if strings.HasPrefix(entry, "!") || (strings.Contains(entry, ":") && !isURL) {
+			aliases = append(aliases, entry)
+			continue
+		}
+		catchAll = append(catchAll, entry)
+	}
+
+	return catchAll, aliases
+}
+
+func forwardEmailRecord(domain string, entries []string) *DNSRecord {
+	return &DNSRecord{
+		Type:  "TXT",
+		Name:  domain,
+		Value: forwardEmailRecordPrefix + strings.Join(entries, ","),
+		TTL:   defaultDNSRecordTTL,
+	}
+}
@@ -0,0 +1,337 @@
+package forwardemail
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_GetCatchAllPasswords(t *testing.T) {
+	tests := []struct {
+		name   string
+		domain string
+		res    string
+		want   []CatchAllPassword
+	}{
+		{
+			name: "no data",
+		},
+		{
+			name:   "ok",
+			domain: "stark.com",
+			res: `[
+				{
+				  "id": "6525b03e0bde8f333ace5824",
+				  "description": "shared mailbox",
+				  "created_at": "2023-10-10T20:12:46.588Z"
+				}
+			]`,
+			want: []CatchAllPassword{
+				{
+					Id:          "6525b03e0bde8f333ace5824",
+					Description: "shared mailbox",
+					CreatedAt:   Timestamp{parseTime("2023-10-10T20:12:46.588Z")},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.res)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, _ := c.GetCatchAllPasswords(tt.domain)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_CreateCatchAllPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   CatchAllPasswordParameters
+		res      string
+		wantBody string
+		want     *CatchAllPassword
+	}{
+		{
+			name: "no data",
+		},
+		{
+			name: "ok",
+			params: CatchAllPasswordParameters{
+				NewPassword: pointString("my-custom-password"),
+				Description: pointString("shared mailbox"),
+			},
+			res: `{
+				"id": "6525b03e0bde8f333ace5824",
+				"description": "shared mailbox",
+				"username": "*@stark.com",
+				"password": "my-custom-password"
+			}`,
+			wantBody: "description=shared+mailbox&new_password=my-custom-password",
+			want: &CatchAllPassword{
+				Id:          "6525b03e0bde8f333ace5824",
+				Description: "shared mailbox",
+				Username:    "*@stark.com",
+				Password:    "my-custom-password",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				body = string(b)
+
+				fmt.Fprint(w, tt.res)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, _ := c.CreateCatchAllPassword("stark.com", tt.params)
+			if diff := cmp.Diff(tt.wantBody, body); diff != "" {
+				t.Fatalf("request bodies are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_DeleteCatchAllPassword(t *testing.T) {
+	type response struct {
+		code int
+		body string
+	}
+
+	tests := []struct {
+		name string
+		id   string
+		res  response
+		want error
+	}{
+		{
+			name: "ok",
+			id:   "6525b03e0bde8f333ace5824",
+			res: response{
+				code: http.StatusOK,
+			},
+		},
+		{
+			name: "not ok",
+			res: response{
+				code: http.StatusInternalServerError,
+				body: "oh no",
+			},
+			want: &APIError{StatusCode: http.StatusInternalServerError, Message: "oh no", Body: []byte("oh no")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.res.code)
+				fmt.Fprint(w, tt.res.body)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got := c.DeleteCatchAllPassword("stark.com", tt.id)
+			if diff := cmp.Diff(tt.want, got, cmp.Comparer(equateErrorMessage)); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+type fakeResolver map[string][]string
+
+func (r fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	records, ok := r[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+
+	return records, nil
+}
+
+var freeDomainResolver = fakeResolver{
+	"stark.com": {
+		"forward-email-site-verification=abc",
+		"forward-email=support:pepper@stark.com,tony@stark.com,!old",
+		"forward-email=https://stark.com/hook",
+	},
+}
+
+// catchAllServer answers for a free stark.com domain and a team wayne.com domain, whose
+// catch-all alias exists when alias is not empty.
+func catchAllServer(alias string, calls *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*calls = append(*calls, r.Method+" "+r.URL.Path+" "+string(body))
+
+		switch {
+		case r.URL.Path == "/v1/domains/stark.com":
+			fmt.Fprint(w, `{"name": "stark.com", "plan": "free"}`)
+		case r.URL.Path == "/v1/domains/wayne.com":
+			fmt.Fprint(w, `{"name": "wayne.com", "plan": "team"}`)
+		case alias == "" && (r.Method == "GET" || r.Method == "DELETE"):
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Alias does not exist."}`)
+		case r.Method == "POST" || r.Method == "PUT":
+			fmt.Fprint(w, `{"name": "*", "recipients": ["alfred@wayne.com"]}`)
+		default:
+			fmt.Fprint(w, alias)
+		}
+	}))
+}
+
+func TestClient_GetCatchAll(t *testing.T) {
+	tests := []struct {
+		name   string
+		domain string
+		alias  string
+		want   *CatchAll
+	}{
+		{
+			name:   "free plan",
+			domain: "stark.com",
+			want: &CatchAll{
+				Mechanism:  CatchAllMechanismDNS,
+				Recipients: []string{"tony@stark.com", "https://stark.com/hook"},
+				Record: &DNSRecord{
+					Type:  "TXT",
+					Name:  "stark.com",
+					Value: "forward-email=support:pepper@stark.com,tony@stark.com,!old,https://stark.com/hook",
+					TTL:   defaultDNSRecordTTL,
+				},
+			},
+		},
+		{
+			name:   "paid plan",
+			domain: "wayne.com",
+			alias:  `{"name": "*", "recipients": ["bruce@wayne.com"]}`,
+			want: &CatchAll{
+				Mechanism:  CatchAllMechanismAlias,
+				Recipients: []string{"bruce@wayne.com"},
+				Alias:      &Alias{Name: "*", Recipients: []string{"bruce@wayne.com"}},
+			},
+		},
+		{
+			name:   "paid plan without catch-all",
+			domain: "wayne.com",
+			want:   &CatchAll{Mechanism: CatchAllMechanismAlias},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			svr := catchAllServer(tt.alias, &calls)
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			}, WithTXTResolver(freeDomainResolver))
+
+			got, err := c.GetCatchAll(tt.domain)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_SetCatchAll(t *testing.T) {
+	tests := []struct {
+		name       string
+		domain     string
+		alias      string
+		recipients []string
+		wantRecord string
+		wantCalls  []string
+	}{
+		{
+			name:       "free plan",
+			domain:     "stark.com",
+			recipients: []string{"happy@stark.com"},
+			wantRecord: "forward-email=support:pepper@stark.com,!old,happy@stark.com",
+			wantCalls:  []string{"GET /v1/domains/stark.com "},
+		},
+		{
+			name:       "paid plan",
+			domain:     "wayne.com",
+			alias:      `{"name": "*", "recipients": ["bruce@wayne.com"]}`,
+			recipients: []string{"alfred@wayne.com"},
+			wantCalls: []string{
+				"GET /v1/domains/wayne.com ",
+				"GET /v1/domains/wayne.com/aliases/* ",
+				`PUT /v1/domains/wayne.com/aliases/* {"recipients":["alfred@wayne.com"]}`,
+			},
+		},
+		{
+			name:   "paid plan without recipients",
+			domain: "wayne.com",
+			wantCalls: []string{
+				"GET /v1/domains/wayne.com ",
+				"DELETE /v1/domains/wayne.com/aliases/* ",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			svr := catchAllServer(tt.alias, &calls)
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			}, WithTXTResolver(freeDomainResolver))
+
+			got, err := c.SetCatchAll(tt.domain, tt.recipients)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var record string
+			if got.Record != nil {
+				record = got.Record.Value
+			}
+			if diff := cmp.Diff(tt.wantRecord, record); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.wantCalls, calls); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
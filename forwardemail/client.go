@@ -1,9 +1,18 @@
 package forwardemail
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -13,55 +22,301 @@ const (
 type ClientOptions struct {
 	ApiKey string
 	ApiUrl string
+
+	// ApiKeyFunc, when set, is called before every request to obtain the API key,
+	// which allows keys to be rotated without rebuilding the client.
+	ApiKeyFunc func() (string, error)
+
+	// Credentials provides the API key when neither ApiKey nor ApiKeyFunc is set.
+	// It defaults to DefaultCredentials.
+	Credentials CredentialsProvider
+
+	// RetryPolicy enables automatic retries of rate-limited and transiently failing requests.
+	RetryPolicy *RetryPolicy
 }
 
+// Client is safe for concurrent use by multiple goroutines, and should be shared rather
+// than created per request so connections are reused. Its exported fields must not be
+// changed once requests are being sent; use SetAPIKey to rotate the API key.
 type Client struct {
-	ApiKey string
-	ApiUrl string
+	ApiKey      string
+	ApiUrl      string
+	ApiKeyFunc  func() (string, error)
+	RetryPolicy *RetryPolicy
+	UserAgent   string
+	Headers     http.Header
 
 	HttpClient *http.Client
+
+	mu            sync.RWMutex
+	timeout       time.Duration
+	requestHooks  []func(*http.Request)
+	responseHooks []func(*http.Response)
+
+	hideSecretsFromHooks bool
+	auth                 Authenticator
+	aliasAuth            Authenticator
+	credentials          CredentialsProvider
+	dryRun               bool
+	strictDecoding       bool
+	// optionErr is the error of an option that couldn't be applied, returned by every request.
+	optionErr error
+
+	rateLimit         *RateLimit
+	rateLimitCallback func(RateLimit)
+	limiter           *tokenBucket
+	lastResponse      *Response
+	features          *Features
+
+	logger   *slog.Logger
+	logLevel slog.Level
+
+	cache    Cache
+	cacheTTL time.Duration
+
+	clock   Clock
+	flights *flightGroup
+	stats   Stats
+
+	resolver TXTResolver
 }
 
 // NewClient returns a new Forward Email API Client.
-func NewClient(options ClientOptions) *Client {
+// Options are applied after ClientOptions and take precedence over them.
+func NewClient(options ClientOptions, opts ...Option) *Client {
 	apiUrl := forwardemailApiUrl
 	if options.ApiUrl != "" {
 		apiUrl = options.ApiUrl
 	}
 
-	return &Client{
-		ApiKey:     options.ApiKey,
-		ApiUrl:     apiUrl,
-		HttpClient: http.DefaultClient,
+	c := &Client{
+		ApiKey:      options.ApiKey,
+		ApiUrl:      apiUrl,
+		ApiKeyFunc:  options.ApiKeyFunc,
+		RetryPolicy: options.RetryPolicy,
+		HttpClient:  defaultHTTPClient,
+		credentials: options.Credentials,
+		logLevel:    slog.LevelDebug,
+		clock:       systemClock{},
+		resolver:    net.DefaultResolver,
+	}
+
+	if c.credentials == nil {
+		c.credentials = DefaultCredentials()
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.timeout > 0 {
+		httpClient := *c.HttpClient
+		httpClient.Timeout = c.timeout
+		c.HttpClient = &httpClient
+	}
+
+	return c
+}
+
+// SetAPIKey replaces the API key used for subsequent requests. It is safe to call
+// while other goroutines are using the client.
+func (c *Client) SetAPIKey(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ApiKey = key
+}
+
+func (c *Client) apiKey() (string, error) {
+	c.mu.RLock()
+	key, keyFunc := c.ApiKey, c.ApiKeyFunc
+	c.mu.RUnlock()
+
+	if keyFunc != nil {
+		return keyFunc()
+	}
+	if key == "" && c.credentials != nil {
+		return c.credentials.APIKey()
 	}
+
+	return key, nil
+}
+
+// NewRequest builds a request against the API base URL with authentication applied.
+// It is meant to be used together with DoRaw for endpoints or headers the typed methods don't cover.
+func (c *Client) NewRequest(method, path string, body io.Reader) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, path, body)
 }
 
-func (c *Client) newRequest(method, path string) (*http.Request, error) {
-	req, err := http.NewRequest(method, c.ApiUrl+path, nil)
+// NewRequestWithContext is like NewRequest but attaches ctx to the request.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	if c.optionErr != nil {
+		return nil, c.optionErr
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.ApiUrl+path, body)
 	if err != nil {
 		return nil, err
 	}
 
-	req.SetBasicAuth(c.ApiKey, "")
+	if err := c.authenticator().Authenticate(req); err != nil {
+		return nil, err
+	}
+
+	for k, v := range c.Headers {
+		req.Header[k] = append(req.Header[k], v...)
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
 
 	return req, nil
 }
 
-func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+// DoRaw sends the request and returns the unprocessed response, whatever its status code.
+// Retries are applied according to the client RetryPolicy. The caller is responsible for
+// closing the response body.
+func (c *Client) DoRaw(req *http.Request) (*http.Response, error) {
+	if c.RetryPolicy.enabled() {
+		return c.doWithRetry(req, c.RetryPolicy)
+	}
+
+	return c.send(req)
+}
+
+// send performs a single attempt, running the request and response hooks around it. Like
+// http.Client.Do, it closes the request body even when the request isn't sent, which stops
+// the goroutines writing streamed bodies.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.isDryRun(req) {
+		return c.dryRunResponse(req)
+	}
+
+	if err := c.waitRateLimit(req.Context()); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	runHooks := !c.hideSecretsFromHooks || !HasSecrets(req.Context())
+
+	if runHooks {
+		for _, hook := range c.requestHooks {
+			hook(req)
+		}
+	}
+
+	start := time.Now()
 	res, err := c.HttpClient.Do(req)
+	elapsed := time.Since(start)
+	c.logRequest(req, res, err, elapsed)
+	c.recordStats(req, res, err, elapsed)
 	if err != nil {
 		return nil, err
 	}
 
-	defer res.Body.Close()
-	body, err := io.ReadAll(res.Body)
+	c.updateRateLimit(res)
+	c.recordResponse(req, res)
+
+	if runHooks {
+		for _, hook := range c.responseHooks {
+			hook(res)
+		}
+	}
+
+	return res, nil
+}
+
+// pathf formats an API path, escaping every segment substituted into format so names
+// containing slashes, "*" or non-ASCII characters stay within their path segment. The domain
+// of "/v1/domains/%s" paths is converted to its ASCII form first.
+func pathf(format string, segments ...string) string {
+	args := make([]any, len(segments))
+	for i, segment := range segments {
+		if i == 0 && strings.HasPrefix(format, "/v1/domains/%s") {
+			segment = domainPathSegment(segment)
+		}
+		args[i] = url.PathEscape(segment)
+	}
+
+	return fmt.Sprintf(format, args...)
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string) (*http.Request, error) {
+	return c.NewRequestWithContext(ctx, method, path, nil)
+}
+
+func setJSONBody(req *http.Request, v any) error {
+	body, err := json.Marshal(v)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	setBody(req, "application/json", body)
+
+	return nil
+}
+
+func setFormBody(req *http.Request, params url.Values) {
+	setBody(req, "application/x-www-form-urlencoded", []byte(params.Encode()))
+}
+
+// setBody sets GetBody and ContentLength along with the body, so the request can be
+// replayed by retries and redirects and is never sent chunked.
+func setBody(req *http.Request, contentType string, body []byte) {
+	req.ContentLength = int64(len(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.Body, _ = req.GetBody()
+	req.Header.Set("Content-Type", contentType)
+}
+
+func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	body, _, err := c.doRequestWithHeader(req)
+
+	return body, err
+}
+
+func (c *Client) doRequestWithHeader(req *http.Request) ([]byte, http.Header, error) {
+	if c.flights != nil && req.Method == http.MethodGet {
+		return c.flights.do(cacheKey(req), func() ([]byte, http.Header, error) {
+			return c.doUncoalescedRequest(req)
+		})
+	}
+
+	return c.doUncoalescedRequest(req)
+}
+
+func (c *Client) doUncoalescedRequest(req *http.Request) ([]byte, http.Header, error) {
+	if c.cache != nil {
+		return c.doCachedRequest(req)
+	}
+
+	res, body, err := c.readResponse(req)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	if res.StatusCode == http.StatusOK || res.StatusCode == http.StatusNoContent {
-		return body, err
+		return body, res.Header, err
+	}
+
+	return nil, nil, c.newAPIError(res, body)
+}
+
+func (c *Client) readResponse(req *http.Request) (*http.Response, []byte, error) {
+	res, err := c.DoRaw(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+	return res, body, nil
 }
@@ -1,10 +1,16 @@
 package forwardemail
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 func TestNewClient(t *testing.T) {
@@ -17,8 +23,7 @@ func TestNewClient(t *testing.T) {
 			name:    "empty options",
 			options: ClientOptions{},
 			want: &Client{
-				ApiUrl:     "https://api.forwardemail.net",
-				HttpClient: &http.Client{},
+				ApiUrl: "https://api.forwardemail.net",
 			},
 		},
 		{
@@ -27,9 +32,8 @@ func TestNewClient(t *testing.T) {
 				ApiKey: "4e4d6c332b6fe62a63afe56171fd3725",
 			},
 			want: &Client{
-				ApiKey:     "4e4d6c332b6fe62a63afe56171fd3725",
-				ApiUrl:     "https://api.forwardemail.net",
-				HttpClient: &http.Client{},
+				ApiKey: "4e4d6c332b6fe62a63afe56171fd3725",
+				ApiUrl: "https://api.forwardemail.net",
 			},
 		},
 		{
@@ -38,8 +42,7 @@ func TestNewClient(t *testing.T) {
 				ApiUrl: "https://google.com",
 			},
 			want: &Client{
-				ApiUrl:     "https://google.com",
-				HttpClient: &http.Client{},
+				ApiUrl: "https://google.com",
 			},
 		},
 		{
@@ -49,9 +52,8 @@ func TestNewClient(t *testing.T) {
 				ApiUrl: "https://google.com",
 			},
 			want: &Client{
-				ApiKey:     "4e4d6c332b6fe62a63afe56171fd3725",
-				ApiUrl:     "https://google.com",
-				HttpClient: &http.Client{},
+				ApiKey: "4e4d6c332b6fe62a63afe56171fd3725",
+				ApiUrl: "https://google.com",
 			},
 		},
 	}
@@ -59,9 +61,298 @@ func TestNewClient(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got := NewClient(tt.options)
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreUnexported(Client{}), cmpopts.IgnoreFields(Client{}, "HttpClient")); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+			if got.HttpClient != defaultHTTPClient {
+				t.Fatalf("expected the default HTTP client, got %+v", got.HttpClient)
+			}
+		})
+	}
+}
+
+func TestClient_SetAPIKey(t *testing.T) {
+	var got []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, _, _ := r.BasicAuth()
+		got = append(got, key)
+
+		fmt.Fprintf(w, `{}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiKey: "old",
+		ApiUrl: svr.URL,
+	})
+
+	_, _ = c.GetAccount()
+	c.SetAPIKey("new")
+	_, _ = c.GetAccount()
+
+	if diff := cmp.Diff([]string{"old", "new"}, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestClient_ApiKeyFunc(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyFunc func() (string, error)
+		want    string
+		wantErr error
+	}{
+		{
+			name: "ok",
+			keyFunc: func() (string, error) {
+				return "fresh", nil
+			},
+			want: "fresh",
+		},
+		{
+			name: "not ok",
+			keyFunc: func() (string, error) {
+				return "", errors.New("vault is sealed")
+			},
+			wantErr: errors.New("vault is sealed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _, _ = r.BasicAuth()
+
+				fmt.Fprintf(w, `{}`)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiKey:     "static",
+				ApiUrl:     svr.URL,
+				ApiKeyFunc: tt.keyFunc,
+			})
+
+			_, err := c.GetAccount()
+			if diff := cmp.Diff(tt.wantErr, err, cmp.Comparer(equateErrorMessage)); diff != "" {
+				t.Fatalf("errors are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_DoRaw(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprintf(w, `{"name": "tony"}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	req, err := c.NewRequest("GET", "/v1/domains/stark.com/aliases/tony", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := c.DoRaw(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	etag := res.Header.Get("ETag")
+	if diff := cmp.Diff(`"v1"`, etag); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	req, _ = c.NewRequest("GET", "/v1/domains/stark.com/aliases/tony", nil)
+	req.Header.Set("If-None-Match", etag)
+
+	res, err = c.DoRaw(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if diff := cmp.Diff(http.StatusNotModified, res.StatusCode); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestClient_Context(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.GetAccountContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func Test_pathf(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		segments []string
+		want     string
+	}{
+		{
+			name:     "plain names",
+			format:   "/v1/domains/%s/aliases/%s",
+			segments: []string{"stark.com", "tony"},
+			want:     "/v1/domains/stark.com/aliases/tony",
+		},
+		{
+			name:     "catch-all alias",
+			format:   "/v1/domains/%s/aliases/%s",
+			segments: []string{"stark.com", "*"},
+			want:     "/v1/domains/stark.com/aliases/%2A",
+		},
+		{
+			name:     "regex alias",
+			format:   "/v1/domains/%s/aliases/%s",
+			segments: []string{"stark.com", "/^support-.+$/i"},
+			want:     "/v1/domains/stark.com/aliases/%2F%5Esupport-.+$%2Fi",
+		},
+		{
+			name:     "unicode domain",
+			format:   "/v1/domains/%s/verify-records",
+			segments: []string{"bücher.de"},
+			want:     "/v1/domains/xn--bcher-kva.de/verify-records",
+		},
+		{
+			name:     "unicode alias",
+			format:   "/v1/domains/%s/aliases/%s",
+			segments: []string{"bücher.de", "zoë"},
+			want:     "/v1/domains/xn--bcher-kva.de/aliases/zo%C3%AB",
+		},
+		{
+			name:     "path traversal",
+			format:   "/v1/domains/%s",
+			segments: []string{"../account"},
+			want:     "/v1/domains/..%2Faccount",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pathf(tt.format, tt.segments...)
 			if diff := cmp.Diff(tt.want, got); diff != "" {
 				t.Fatalf("values are not the same %s", diff)
 			}
 		})
 	}
 }
+
+func TestClient_EscapesPathSegments(t *testing.T) {
+	var got []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.EscapedPath())
+
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	_, _ = c.GetDomain("bücher.de")
+	_ = c.DeleteAlias("bücher.de", "/^support/")
+	_ = c.DeleteCatchAllPassword("bücher.de", "a/b")
+
+	want := []string{
+		"/v1/domains/xn--bcher-kva.de",
+		"/v1/domains/xn--bcher-kva.de/aliases/%2F%5Esupport%2F",
+		"/v1/domains/xn--bcher-kva.de/catch-all-passwords/a%2Fb",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestClient_ReplaysRequestBodies(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(c *Client) error
+		want string
+	}{
+		{
+			name: "form body",
+			call: func(c *Client) error {
+				_, err := c.CreateDomain("stark.com", DomainParameters{})
+				return err
+			},
+			want: "domain=stark.com",
+		},
+		{
+			name: "json body",
+			call: func(c *Client) error {
+				_, err := c.CreateAlias("stark.com", "tony", AliasParameters{})
+				return err
+			},
+			want: `{"name":"tony"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies []string
+			var lengths []int64
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(b))
+				lengths = append(lengths, r.ContentLength)
+
+				switch len(bodies) {
+				case 1:
+					// A redirect preserving the method must carry the body again.
+					http.Redirect(w, r, r.URL.Path, http.StatusTemporaryRedirect)
+				case 2:
+					w.WriteHeader(http.StatusTooManyRequests)
+				default:
+					fmt.Fprint(w, `{}`)
+				}
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl:      svr.URL,
+				RetryPolicy: &RetryPolicy{MaxAttempts: 2},
+			})
+
+			if err := tt.call(c); err != nil {
+				t.Fatal(err)
+			}
+
+			n := int64(len(tt.want))
+			if diff := cmp.Diff([]string{tt.want, tt.want, tt.want}, bodies); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+			if diff := cmp.Diff([]int64{n, n, n}, lengths); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
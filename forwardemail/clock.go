@@ -0,0 +1,40 @@
+package forwardemail
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the time and waits for the client: retry backoffs, the WaitFor helpers and
+// cache expiry go through it. Replace it with WithClock to test them deterministically; see
+// forwardemailtest.Clock.
+type Clock interface {
+	Now() time.Time
+	// Sleep waits for d, or until ctx is done, in which case it returns ctx.Err().
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// WithClock makes the client use clock instead of the system clock.
+func WithClock(clock Clock) Option {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
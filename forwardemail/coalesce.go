@@ -0,0 +1,59 @@
+package forwardemail
+
+import (
+	"net/http"
+	"sync"
+)
+
+// WithRequestCoalescing makes concurrent identical GET requests for a single resource, such
+// as GetAlias or GetDomain, share one HTTP request: callers arriving while a request is in
+// flight wait for it and get its result. Requests are identical when their URL and API key
+// are. As the request is sent with the context of the first caller, its cancellation fails
+// the callers waiting on it too.
+func WithRequestCoalescing() Option {
+	return func(c *Client) {
+		c.flights = &flightGroup{}
+	}
+}
+
+// flightGroup runs a single request per key at a time, sharing its result between the
+// callers asking for it meanwhile.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+type flight struct {
+	done   chan struct{}
+	body   []byte
+	header http.Header
+	err    error
+}
+
+func (g *flightGroup) do(key string, fn func() ([]byte, http.Header, error)) ([]byte, http.Header, error) {
+	g.mu.Lock()
+	if f, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		<-f.done
+
+		return f.body, f.header.Clone(), f.err
+	}
+
+	f := &flight{done: make(chan struct{})}
+	if g.calls == nil {
+		g.calls = map[string]*flight{}
+	}
+	g.calls[key] = f
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(f.done)
+	}()
+
+	f.body, f.header, f.err = fn()
+
+	return f.body, f.header.Clone(), f.err
+}
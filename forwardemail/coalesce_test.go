@@ -0,0 +1,108 @@
+package forwardemail
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithRequestCoalescing(t *testing.T) {
+	var hits atomic.Int32
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		// Give the other callers time to join the request in flight.
+		time.Sleep(50 * time.Millisecond)
+
+		fmt.Fprintf(w, `{"name": %q}`, r.URL.Path)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	}, WithRequestCoalescing())
+
+	var wg sync.WaitGroup
+	names := make([]string, 5)
+	for i := range names {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if alias, err := c.GetAlias("stark.com", "tony"); err == nil {
+				names[i] = alias.Name
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected a single request, got %d", got)
+	}
+	for _, name := range names {
+		if name != "/v1/domains/stark.com/aliases/tony" {
+			t.Errorf("unexpected alias %q", name)
+		}
+	}
+
+	if _, err := c.GetAlias("stark.com", "tony"); err != nil {
+		t.Fatal(err)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("expected a new request once the first one is done, got %d requests", got)
+	}
+}
+
+func TestClient_GetAliasesByIDs(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+
+		if r.URL.Path == "/v1/domains/stark.com/aliases/bbbbbbbbbbbbbbbbbbbbbbbb" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Alias does not exist."}`)
+			return
+		}
+		fmt.Fprint(w, `{"name": "tony"}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	ids := []string{"aaaaaaaaaaaaaaaaaaaaaaaa", "bbbbbbbbbbbbbbbbbbbbbbbb", "tony", "aaaaaaaaaaaaaaaaaaaaaaaa"}
+	results := c.GetAliasesByIDs("stark.com", ids, BulkOptions{})
+
+	var got []string
+	for _, result := range results {
+		switch {
+		case result.Alias != nil:
+			got = append(got, result.Name+" "+result.Alias.Name)
+		case IsNotFound(result.Err):
+			got = append(got, result.Name+" not found")
+		default:
+			got = append(got, result.Name+" invalid")
+		}
+	}
+
+	want := []string{
+		"aaaaaaaaaaaaaaaaaaaaaaaa tony",
+		"bbbbbbbbbbbbbbbbbbbbbbbb not found",
+		"tony invalid",
+		"aaaaaaaaaaaaaaaaaaaaaaaa tony",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+	if len(paths) != 2 {
+		t.Errorf("expected one request per valid distinct ID, got %v", paths)
+	}
+}
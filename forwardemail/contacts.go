@@ -0,0 +1,112 @@
+package forwardemail
+
+import (
+	"context"
+)
+
+// Contact is a CardDAV contact of an alias mailbox. Content is the full vCard, the other
+// fields are parsed from it by the API.
+type Contact struct {
+	Id           string         `json:"id"`
+	Object       string         `json:"object"`
+	Uid          string         `json:"uid"`
+	FullName     string         `json:"full_name"`
+	Emails       []ContactValue `json:"emails"`
+	PhoneNumbers []ContactValue `json:"phone_numbers"`
+	Content      string         `json:"content"`
+	ETag         string         `json:"etag"`
+	IsGroup      bool           `json:"is_group"`
+	CreatedAt    Timestamp      `json:"created_at"`
+	UpdatedAt    Timestamp      `json:"updated_at"`
+}
+
+// ContactValue is an email address or phone number of a contact, with its vCard type such as "work".
+type ContactValue struct {
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+// ContactParameters are sent as a JSON body. Content, when set, is a vCard and takes
+// precedence over the other fields.
+type ContactParameters struct {
+	FullName     string         `json:"full_name,omitempty"`
+	Emails       []ContactValue `json:"emails,omitempty"`
+	PhoneNumbers []ContactValue `json:"phone_numbers,omitempty"`
+	Content      string         `json:"content,omitempty"`
+}
+
+// GetContacts returns the contacts of the mailbox of the alias credentials.
+func (c *Client) GetContacts(opts ...RequestOption) ([]Contact, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetContactsContext(ctx)
+}
+
+func (c *Client) GetContactsContext(ctx context.Context) ([]Contact, error) {
+	var items []Contact
+	if err := c.doMailboxJSON(ctx, "GET", "/v1/contacts", nil, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (c *Client) GetContact(id string, opts ...RequestOption) (*Contact, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetContactContext(ctx, id)
+}
+
+func (c *Client) GetContactContext(ctx context.Context, id string) (*Contact, error) {
+	var item Contact
+	if err := c.doMailboxJSON(ctx, "GET", pathf("/v1/contacts/%s", id), nil, &item); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) CreateContact(parameters ContactParameters, opts ...RequestOption) (*Contact, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.CreateContactContext(ctx, parameters)
+}
+
+func (c *Client) CreateContactContext(ctx context.Context, parameters ContactParameters) (*Contact, error) {
+	var item Contact
+	if err := c.doMailboxJSON(ctx, "POST", "/v1/contacts", parameters, &item); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) UpdateContact(id string, parameters ContactParameters, opts ...RequestOption) (*Contact, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.UpdateContactContext(ctx, id, parameters)
+}
+
+func (c *Client) UpdateContactContext(ctx context.Context, id string, parameters ContactParameters) (*Contact, error) {
+	var item Contact
+	if err := c.doMailboxJSON(ctx, "PUT", pathf("/v1/contacts/%s", id), parameters, &item); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) DeleteContact(id string, opts ...RequestOption) error {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.DeleteContactContext(ctx, id)
+}
+
+func (c *Client) DeleteContactContext(ctx context.Context, id string) error {
+	return c.doMailboxJSON(ctx, "DELETE", pathf("/v1/contacts/%s", id), nil, nil)
+}
@@ -0,0 +1,67 @@
+package forwardemail
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_Contacts(t *testing.T) {
+	var calls []string
+	svr := newMailboxServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+string(body))
+
+		switch r.Method {
+		case "GET":
+			if r.URL.Path == "/v1/contacts" {
+				fmt.Fprint(w, `[{"id": "c1", "full_name": "Pepper Potts", "emails": [{"value": "pepper@stark.com", "type": "work"}]}]`)
+				return
+			}
+			fmt.Fprint(w, `{"id": "c1", "full_name": "Pepper Potts"}`)
+		case "DELETE":
+			fmt.Fprint(w, `{}`)
+		default:
+			fmt.Fprint(w, `{"id": "c1", "full_name": "Pepper Potts", "emails": [{"value": "pepper@stark.com"}]}`)
+		}
+	})
+
+	c := NewClient(ClientOptions{ApiUrl: svr.URL}, WithAliasCredentials("tony@stark.com", "secret"))
+
+	contacts, err := c.GetContacts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Contact{{Id: "c1", FullName: "Pepper Potts", Emails: []ContactValue{{Value: "pepper@stark.com", Type: "work"}}}}
+	if diff := cmp.Diff(want, contacts); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+
+	parameters := ContactParameters{FullName: "Pepper Potts", Emails: []ContactValue{{Value: "pepper@stark.com"}}}
+	if _, err := c.CreateContact(parameters); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.UpdateContact("c1", parameters); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetContact("c1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteContact("c1"); err != nil {
+		t.Fatal(err)
+	}
+
+	wantCalls := []string{
+		"GET /v1/contacts ",
+		`POST /v1/contacts {"full_name":"Pepper Potts","emails":[{"value":"pepper@stark.com"}]}`,
+		`PUT /v1/contacts/c1 {"full_name":"Pepper Potts","emails":[{"value":"pepper@stark.com"}]}`,
+		"GET /v1/contacts/c1 ",
+		"DELETE /v1/contacts/c1 ",
+	}
+	if diff := cmp.Diff(wantCalls, calls); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+}
@@ -0,0 +1,158 @@
+package forwardemail
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIKeyEnv is the environment variable read by EnvCredentials.
+const APIKeyEnv = "FORWARDEMAIL_API_KEY"
+
+// CredentialsProvider supplies the API key. APIKey is called before every request, so a
+// provider can return a rotated key without the client being rebuilt. A provider without
+// a key returns an empty key and no error, which lets ChainCredentials try the next one.
+type CredentialsProvider interface {
+	APIKey() (string, error)
+}
+
+// CredentialsFunc adapts a function to a CredentialsProvider.
+type CredentialsFunc func() (string, error)
+
+func (f CredentialsFunc) APIKey() (string, error) {
+	return f()
+}
+
+// StaticCredentials is a fixed API key.
+type StaticCredentials string
+
+func (s StaticCredentials) APIKey() (string, error) {
+	return string(s), nil
+}
+
+// EnvCredentials reads the API key from the FORWARDEMAIL_API_KEY environment variable.
+type EnvCredentials struct{}
+
+func (EnvCredentials) APIKey() (string, error) {
+	return os.Getenv(APIKeyEnv), nil
+}
+
+// FileCredentials reads the API key from a file, which is read again whenever it changes.
+// The file is either a JSON object with an "api_key" field, as written for the forwardemail
+// command, or text holding the key alone or an "api_key = ..." line; blank lines and lines
+// starting with # are ignored. A missing file has no key.
+type FileCredentials struct {
+	Path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	key     string
+}
+
+// NewFileCredentials returns a provider reading the key from the file at path.
+func NewFileCredentials(path string) *FileCredentials {
+	return &FileCredentials{Path: path}
+}
+
+func (f *FileCredentials) APIKey() (string, error) {
+	info, err := os.Stat(f.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.key, nil
+	}
+
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := parseCredentialsFile(data)
+	if err != nil {
+		return "", fmt.Errorf("reading credentials %s: %w", f.Path, err)
+	}
+
+	f.modTime, f.size, f.key = info.ModTime(), info.Size(), key
+
+	return key, nil
+}
+
+func parseCredentialsFile(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("{")) {
+		var file struct {
+			ApiKey string `json:"api_key"`
+		}
+		if err := json.Unmarshal(data, &file); err != nil {
+			return "", err
+		}
+
+		return file.ApiKey, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, value, found := strings.Cut(line, "=")
+		if !found {
+			return line, nil
+		}
+		if strings.TrimSpace(name) == "api_key" {
+			return strings.Trim(strings.TrimSpace(value), `"'`), nil
+		}
+	}
+
+	return "", scanner.Err()
+}
+
+// ChainCredentials returns the first key found by providers, in order. An error from a
+// provider stops the chain.
+func ChainCredentials(providers ...CredentialsProvider) CredentialsProvider {
+	return CredentialsFunc(func() (string, error) {
+		for _, provider := range providers {
+			key, err := provider.APIKey()
+			if err != nil || key != "" {
+				return key, err
+			}
+		}
+
+		return "", nil
+	})
+}
+
+// DefaultCredentials looks the API key up in the FORWARDEMAIL_API_KEY environment variable,
+// then in ~/.forwardemailrc, then in forwardemail/config.json in the user config directory
+// ($XDG_CONFIG_HOME on Linux). It is used by NewClient when no API key is given.
+func DefaultCredentials() CredentialsProvider {
+	providers := []CredentialsProvider{EnvCredentials{}}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		providers = append(providers, NewFileCredentials(filepath.Join(home, ".forwardemailrc")))
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		providers = append(providers, NewFileCredentials(filepath.Join(dir, "forwardemail", "config.json")))
+	}
+
+	return ChainCredentials(providers...)
+}
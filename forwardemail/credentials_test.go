@@ -0,0 +1,120 @@
+package forwardemail
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseCredentialsFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty"},
+		{name: "bare key", data: "\n# my key\nabc123\n", want: "abc123"},
+		{name: "assignment", data: "api_key = \"abc123\"\n", want: "abc123"},
+		{name: "other assignments", data: "api_url=https://example.com\n", want: ""},
+		{name: "json", data: `{"api_key": "abc123", "api_url": "https://example.com"}`, want: "abc123"},
+		{name: "invalid json", data: `{"api_key": `, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCredentialsFile([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestFileCredentials_Rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".forwardemailrc")
+	provider := NewFileCredentials(path)
+
+	key, err := provider.APIKey()
+	if err != nil || key != "" {
+		t.Fatalf("expected no key for a missing file, got %q, %v", key, err)
+	}
+
+	if err := os.WriteFile(path, []byte("first"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if key, _ := provider.APIKey(); key != "first" {
+		t.Fatalf("expected the first key, got %q", key)
+	}
+
+	if err := os.WriteFile(path, []byte("second"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// Make sure the change is visible even on file systems with a coarse modification time.
+	if err := os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if key, _ := provider.APIKey(); key != "second" {
+		t.Fatalf("expected the rotated key, got %q", key)
+	}
+}
+
+func TestChainCredentials(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers []CredentialsProvider
+		want      string
+		wantErr   bool
+	}{
+		{name: "none"},
+		{name: "first with a key", providers: []CredentialsProvider{StaticCredentials(""), StaticCredentials("b"), StaticCredentials("c")}, want: "b"},
+		{name: "error stops the chain", providers: []CredentialsProvider{
+			CredentialsFunc(func() (string, error) { return "", errors.New("vault is sealed") }),
+			StaticCredentials("b"),
+		}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ChainCredentials(tt.providers...).APIKey()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestNewClient_Credentials(t *testing.T) {
+	var got []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, _, _ := r.BasicAuth()
+		got = append(got, key)
+
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	t.Setenv(APIKeyEnv, "from_env")
+
+	_, _ = NewClient(ClientOptions{ApiUrl: svr.URL}).GetAccount()
+	_, _ = NewClient(ClientOptions{ApiUrl: svr.URL, Credentials: StaticCredentials("from_provider")}).GetAccount()
+	_, _ = NewClient(ClientOptions{ApiUrl: svr.URL, ApiKey: "explicit", Credentials: StaticCredentials("from_provider")}).GetAccount()
+
+	want := []string{"from_env", "from_provider", "explicit"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+}
@@ -0,0 +1,57 @@
+package forwardemail
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// APIVersion is the version of the Forward Email API the client is written against. The API
+// is versioned by the path of its endpoints, /v1, and doesn't negotiate versions through a
+// header, so every request of the client is pinned to it. Changes within a version add fields,
+// which responses tolerate unless WithStrictDecoding is used.
+const APIVersion = "v1"
+
+// WithStrictDecoding makes responses carrying fields unknown to the types of this package fail
+// to decode rather than having those fields ignored, to fail fast on upstream schema changes,
+// for example in tests run against the API. Fields decoded by a type's own UnmarshalJSON, such
+// as GeneratedPassword.Extra, aren't checked.
+func WithStrictDecoding() Option {
+	return func(c *Client) {
+		c.strictDecoding = true
+	}
+}
+
+// newDecoder returns a JSON decoder of r following the decoding mode of the client.
+func (c *Client) newDecoder(r io.Reader) *json.Decoder {
+	dec := json.NewDecoder(r)
+	if c.strictDecoding {
+		dec.DisallowUnknownFields()
+	}
+
+	return dec
+}
+
+// unmarshal is json.Unmarshal of the response to req following the decoding mode of the
+// client. Dry-run responses echo the request body, which has fields the result type may
+// lack, so they are never decoded strictly.
+func (c *Client) unmarshal(req *http.Request, data []byte, v any) error {
+	if !c.strictDecoding || c.isDryRun(req) {
+		return json.Unmarshal(data, v)
+	}
+
+	dec := c.newDecoder(bytes.NewReader(data))
+	if err := dec.Decode(v); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("json: unexpected data after the top-level value")
+	}
+
+	return nil
+}
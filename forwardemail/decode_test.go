@@ -0,0 +1,79 @@
+package forwardemail
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithStrictDecoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		strict  bool
+		list    bool
+		body    string
+		wantErr bool
+	}{
+		{name: "known fields", strict: true, body: `{"name": "tony", "is_enabled": true}`},
+		{name: "unknown field ignored by default", body: `{"name": "tony", "is_shiny": true}`},
+		{name: "unknown field rejected", strict: true, body: `{"name": "tony", "is_shiny": true}`, wantErr: true},
+		{name: "unknown field of a list rejected", strict: true, list: true, body: `[{"name": "tony", "is_shiny": true}]`, wantErr: true},
+		{name: "trailing data", strict: true, body: `{"name": "tony"} {}`, wantErr: true},
+		{name: "empty body", strict: true, body: ``, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.body)
+			}))
+			defer svr.Close()
+
+			var opts []Option
+			if tt.strict {
+				opts = append(opts, WithStrictDecoding())
+			}
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			}, opts...)
+
+			var err error
+			if tt.list {
+				_, err = c.GetAliases("stark.com")
+			} else {
+				_, err = c.GetAlias("stark.com", "tony")
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error %v", err)
+			}
+		})
+	}
+}
+
+// The responses decoded as a string fall back to the raw body, which strict decoding of
+// trailing data then returns whole.
+func TestWithStrictDecoding_StringResponses(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `"ok" {}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	}, WithStrictDecoding())
+
+	encrypted, err := c.EncryptTXT("forward-email=tony@stark.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := c.VerifyDomainRecords("stark.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{`"ok" {}`, `"ok" {}`}, []string{encrypted, message}); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
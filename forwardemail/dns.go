@@ -0,0 +1,87 @@
+package forwardemail
+
+import "fmt"
+
+var forwardemailMxHosts = []string{"mx1.forwardemail.net", "mx2.forwardemail.net"}
+
+const forwardemailReturnPathTarget = "forwardemail.net"
+
+// defaultDNSRecordTTL is the TTL suggested for the records, in seconds.
+const defaultDNSRecordTTL = 3600
+
+// DNSRecord is a provider-agnostic DNS record. Priority is only meaningful for MX records.
+type DNSRecord struct {
+	Type     string
+	Name     string
+	Value    string
+	Priority int
+	TTL      int
+}
+
+// DomainRecords are the DNS records a domain needs for Forward Email to work.
+// DKIM and ReturnPath are only set when the domain has outbound SMTP configured.
+type DomainRecords struct {
+	MX           []DNSRecord
+	Verification DNSRecord
+	DKIM         *DNSRecord
+	ReturnPath   *DNSRecord
+}
+
+// Records returns the records the domain is expected to publish, with fully qualified names.
+func (d *Domain) Records() DomainRecords {
+	records := DomainRecords{
+		Verification: DNSRecord{
+			Type:  "TXT",
+			Name:  d.Name,
+			Value: fmt.Sprintf("forward-email-site-verification=%s", d.VerificationRecord),
+			TTL:   defaultDNSRecordTTL,
+		},
+	}
+
+	for _, host := range forwardemailMxHosts {
+		records.MX = append(records.MX, DNSRecord{
+			Type:     "MX",
+			Name:     d.Name,
+			Value:    host,
+			Priority: 10,
+			TTL:      defaultDNSRecordTTL,
+		})
+	}
+
+	if d.DkimKeySelector != "" && d.DkimPublicKey != "" {
+		records.DKIM = &DNSRecord{
+			Type:  "TXT",
+			Name:  fmt.Sprintf("%s._domainkey.%s", d.DkimKeySelector, d.Name),
+			Value: fmt.Sprintf("v=DKIM1; k=rsa; p=%s;", d.DkimPublicKey),
+			TTL:   defaultDNSRecordTTL,
+		}
+	}
+
+	if d.ReturnPath != "" {
+		records.ReturnPath = &DNSRecord{
+			Type:  "CNAME",
+			Name:  fmt.Sprintf("%s.%s", d.ReturnPath, d.Name),
+			Value: forwardemailReturnPathTarget,
+			TTL:   defaultDNSRecordTTL,
+		}
+	}
+
+	return records
+}
+
+// RequiredDNSRecords returns every record of Records as a flat list, ready to be handed
+// to a DNS provider client.
+func (d *Domain) RequiredDNSRecords() []DNSRecord {
+	records := d.Records()
+
+	all := append([]DNSRecord{}, records.MX...)
+	all = append(all, records.Verification)
+	if records.DKIM != nil {
+		all = append(all, *records.DKIM)
+	}
+	if records.ReturnPath != nil {
+		all = append(all, *records.ReturnPath)
+	}
+
+	return all
+}
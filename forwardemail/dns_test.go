@@ -0,0 +1,105 @@
+package forwardemail
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDomain_Records(t *testing.T) {
+	tests := []struct {
+		name   string
+		domain Domain
+		want   DomainRecords
+	}{
+		{
+			name: "forwarding only",
+			domain: Domain{
+				Name:               "stark.com",
+				VerificationRecord: "v8O0S8JjRv",
+			},
+			want: DomainRecords{
+				MX: []DNSRecord{
+					{Type: "MX", Name: "stark.com", Value: "mx1.forwardemail.net", Priority: 10, TTL: 3600},
+					{Type: "MX", Name: "stark.com", Value: "mx2.forwardemail.net", Priority: 10, TTL: 3600},
+				},
+				Verification: DNSRecord{Type: "TXT", Name: "stark.com", Value: "forward-email-site-verification=v8O0S8JjRv", TTL: 3600},
+			},
+		},
+		{
+			name: "with outbound smtp",
+			domain: Domain{
+				Name:               "stark.com",
+				VerificationRecord: "v8O0S8JjRv",
+				DkimKeySelector:    "fe-4e4d6c332b",
+				DkimPublicKey:      "MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC",
+				ReturnPath:         "fe-bounces",
+			},
+			want: DomainRecords{
+				MX: []DNSRecord{
+					{Type: "MX", Name: "stark.com", Value: "mx1.forwardemail.net", Priority: 10, TTL: 3600},
+					{Type: "MX", Name: "stark.com", Value: "mx2.forwardemail.net", Priority: 10, TTL: 3600},
+				},
+				Verification: DNSRecord{Type: "TXT", Name: "stark.com", Value: "forward-email-site-verification=v8O0S8JjRv", TTL: 3600},
+				DKIM:         &DNSRecord{Type: "TXT", Name: "fe-4e4d6c332b._domainkey.stark.com", Value: "v=DKIM1; k=rsa; p=MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC;", TTL: 3600},
+				ReturnPath:   &DNSRecord{Type: "CNAME", Name: "fe-bounces.stark.com", Value: "forwardemail.net", TTL: 3600},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.domain.Records()
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestDomain_RequiredDNSRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		domain Domain
+		want   []DNSRecord
+	}{
+		{
+			name: "forwarding only",
+			domain: Domain{
+				Name:               "stark.com",
+				VerificationRecord: "v8O0S8JjRv",
+			},
+			want: []DNSRecord{
+				{Type: "MX", Name: "stark.com", Value: "mx1.forwardemail.net", Priority: 10, TTL: 3600},
+				{Type: "MX", Name: "stark.com", Value: "mx2.forwardemail.net", Priority: 10, TTL: 3600},
+				{Type: "TXT", Name: "stark.com", Value: "forward-email-site-verification=v8O0S8JjRv", TTL: 3600},
+			},
+		},
+		{
+			name: "with outbound smtp",
+			domain: Domain{
+				Name:               "stark.com",
+				VerificationRecord: "v8O0S8JjRv",
+				DkimKeySelector:    "fe-4e4d6c332b",
+				DkimPublicKey:      "MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC",
+				ReturnPath:         "fe-bounces",
+			},
+			want: []DNSRecord{
+				{Type: "MX", Name: "stark.com", Value: "mx1.forwardemail.net", Priority: 10, TTL: 3600},
+				{Type: "MX", Name: "stark.com", Value: "mx2.forwardemail.net", Priority: 10, TTL: 3600},
+				{Type: "TXT", Name: "stark.com", Value: "forward-email-site-verification=v8O0S8JjRv", TTL: 3600},
+				{Type: "TXT", Name: "fe-4e4d6c332b._domainkey.stark.com", Value: "v=DKIM1; k=rsa; p=MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC;", TTL: 3600},
+				{Type: "CNAME", Name: "fe-bounces.stark.com", Value: "forwardemail.net", TTL: 3600},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.domain.RequiredDNSRecords()
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
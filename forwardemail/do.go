@@ -0,0 +1,112 @@
+package forwardemail
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Do sends a request to an endpoint of the API that has no typed method yet, with the same
+// authentication, retries, rate limit tracking and error handling as the typed methods. path
+// is relative to the API URL, e.g. "/v1/domains/stark.com/aliases", and query, when not
+// empty, is added to it. body is sent form-encoded when it is url.Values, as JSON when it is
+// anything else, and not at all when nil. The JSON response is decoded into out unless it is
+// nil or the response has no body. Answers other than 200 OK and 204 No Content are returned
+// as an *APIError.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body any, out any) error {
+	if encoded := query.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+
+	req, err := newRequestWithBody(ctx, c, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if out == nil || len(res) == 0 {
+		return nil
+	}
+
+	return c.unmarshal(req, res, out)
+}
+
+// do sends a request and decodes its JSON response into a new T. body is sent form-encoded
+// when it is url.Values, as JSON otherwise, and not at all when nil.
+func do[T any](ctx context.Context, c *Client, method, path string, body any) (*T, error) {
+	req, err := newRequestWithBody(ctx, c, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var item T
+
+	err = c.unmarshal(req, res, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// doDiscard is do for requests whose response body is of no interest, such as deletes.
+func doDiscard(ctx context.Context, c *Client, method, path string, body any) error {
+	req, err := newRequestWithBody(ctx, c, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	return err
+}
+
+// list returns a page of items from a list endpoint along with its pagination. The items are
+// decoded as the response is read.
+func list[T any](ctx context.Context, c *Client, path string, query url.Values) ([]T, *Pagination, error) {
+	if encoded := query.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+
+	req, err := c.newRequest(ctx, "GET", path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var items []T
+
+	header, err := c.doStreamRequest(req, collectJSONArray(c, &items))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return items, parsePagination(header), nil
+}
+
+func newRequestWithBody(ctx context.Context, c *Client, method, path string, body any) (*http.Request, error) {
+	req, err := c.newRequest(ctx, method, path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch body := body.(type) {
+	case nil:
+	case url.Values:
+		setFormBody(req, body)
+	default:
+		if err := setJSONBody(req, body); err != nil {
+			return nil, err
+		}
+	}
+
+	return req, nil
+}
@@ -0,0 +1,132 @@
+package forwardemail
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_newRequestWithBody(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            any
+		wantContentType string
+		wantBody        string
+	}{
+		{name: "no body"},
+		{name: "form", body: url.Values{"domain": {"stark.com"}}, wantContentType: "application/x-www-form-urlencoded", wantBody: "domain=stark.com"},
+		{name: "json", body: aliasBody{Name: "tony"}, wantContentType: "application/json", wantBody: `{"name":"tony"}`},
+	}
+
+	c := NewClient(ClientOptions{ApiKey: "key"})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := newRequestWithBody(context.Background(), c, "POST", "/v1/domains", tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var body []byte
+			if req.Body != nil {
+				body, _ = io.ReadAll(req.Body)
+			}
+
+			got := []string{req.Header.Get("Content-Type"), string(body)}
+			if diff := cmp.Diff([]string{tt.wantContentType, tt.wantBody}, got); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_Do(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		query    url.Values
+		body     any
+		status   int
+		response string
+		wantCall string
+		want     *Alias
+		wantErr  bool
+	}{
+		{
+			name:     "get with query",
+			method:   "GET",
+			query:    url.Values{"name": {"tony"}},
+			response: `{"name": "tony"}`,
+			wantCall: "GET /v1/domains/stark.com/aliases/tony?name=tony ",
+			want:     &Alias{Name: "tony"},
+		},
+		{
+			name:     "post form",
+			method:   "POST",
+			body:     url.Values{"recipient": {"pepper@stark.com"}},
+			response: `{"name": "tony"}`,
+			wantCall: "POST /v1/domains/stark.com/aliases/tony recipient=pepper%40stark.com",
+			want:     &Alias{Name: "tony"},
+		},
+		{
+			name:     "no content",
+			method:   "DELETE",
+			status:   http.StatusNoContent,
+			wantCall: "DELETE /v1/domains/stark.com/aliases/tony ",
+			want:     &Alias{},
+		},
+		{
+			name:     "api error",
+			method:   "GET",
+			status:   http.StatusNotFound,
+			response: `{"message": "Alias does not exist."}`,
+			wantCall: "GET /v1/domains/stark.com/aliases/tony ",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var call string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				call = r.Method + " " + r.URL.RequestURI() + " " + string(body)
+
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+				fmt.Fprint(w, tt.response)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			var got Alias
+			err := c.Do(context.Background(), tt.method, "/v1/domains/stark.com/aliases/tony", tt.query, tt.body, &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if tt.wantErr {
+				if !IsNotFound(err) {
+					t.Errorf("expected a not found error, got %v", err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(tt.wantCall, call); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, &got); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
@@ -1,13 +1,10 @@
 package forwardemail
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
 	"net/url"
-	"strconv"
 	"strings"
-	"time"
 )
 
 type Domain struct {
@@ -17,159 +14,266 @@ type Domain struct {
 	HasVirusProtection        bool      `json:"has_virus_protection"`
 	IsCatchallRegexDisabled   bool      `json:"is_catchall_regex_disabled"`
 	Plan                      string    `json:"plan"`
+	IsGlobal                  bool      `json:"is_global"`
 	MaxRecipientsPerAlias     int       `json:"max_recipients_per_alias"`
 	SmtpPort                  string    `json:"smtp_port"`
+	HasSMTP                   bool      `json:"has_smtp"`
+	IsSMTPSuspended           bool      `json:"is_smtp_suspended"`
+	BounceWebhook             string    `json:"bounce_webhook"`
 	Name                      string    `json:"name"`
 	HasMxRecord               bool      `json:"has_mx_record"`
 	HasTxtRecord              bool      `json:"has_txt_record"`
 	HasRecipientVerification  bool      `json:"has_recipient_verification"`
 	HasCustomVerification     bool      `json:"has_custom_verification"`
 	VerificationRecord        string    `json:"verification_record"`
+	HasDkimRecord             bool      `json:"has_dkim_record"`
+	HasReturnPathRecord       bool      `json:"has_return_path_record"`
+	HasDmarcRecord            bool      `json:"has_dmarc_record"`
+	DkimKeySelector           string    `json:"dkim_key_selector"`
+	DkimPublicKey             string    `json:"dkim_public_key"`
+	ReturnPath                string    `json:"return_path"`
 	Id                        string    `json:"id"`
 	Object                    string    `json:"object"`
-	CreatedAt                 time.Time `json:"created_at"`
-	UpdatedAt                 time.Time `json:"updated_at"`
+	CreatedAt                 Timestamp `json:"created_at"`
+	UpdatedAt                 Timestamp `json:"updated_at"`
 	Link                      string    `json:"link"`
+	MaxQuotaPerAlias          int64     `json:"max_quota_per_alias"`
+	StorageUsed               int64     `json:"storage_used"`
+	Members                   []Member  `json:"members"`
+	Invites                   []Invite  `json:"invites"`
+	Allowlist                 []string  `json:"allowlist"`
+	Denylist                  []string  `json:"denylist"`
+	RestrictedAliasNames      []string  `json:"restricted_alias_names"`
 }
 
+// DomainParameters are the domain settings to create or update. Nil fields are not sent,
+// leaving the current (or default) value in place.
 type DomainParameters struct {
-	HasAdultContentProtection *bool
-	HasPhishingProtection     *bool
-	HasExecutableProtection   *bool
-	HasVirusProtection        *bool
-	HasRecipientVerification  *bool
+	HasAdultContentProtection *bool   `form:"has_adult_content_protection"`
+	HasPhishingProtection     *bool   `form:"has_phishing_protection"`
+	HasExecutableProtection   *bool   `form:"has_executable_protection"`
+	HasVirusProtection        *bool   `form:"has_virus_protection"`
+	HasRecipientVerification  *bool   `form:"has_recipient_verification"`
+	IgnoreMxCheck             *bool   `form:"ignore_mx_check"`
+	BounceWebhook             *string `form:"bounce_webhook"`
+	SmtpPort                  *string `form:"smtp_port"`
+	MaxRecipientsPerAlias     *int    `form:"max_recipients_per_alias"`
+
+	// The fields below are only sent when creating a domain.
+
+	// Plan is one of PlanFree, PlanEnhancedProtection or PlanTeam.
+	Plan *string `form:"plan,create"`
+	// CatchAll set to false creates the domain without the default catch-all alias,
+	// which otherwise forwards to the email address of the account.
+	CatchAll *bool `form:"catchall,create"`
+	// CatchAllRecipients, when not empty, are the recipients of the catch-all alias instead.
+	CatchAllRecipients []string `form:"-"`
+	// TeamDomain adds the domain to the team plan of the domain with that name.
+	TeamDomain *string `form:"team_domain,create"`
 }
 
-func (c *Client) GetDomains() ([]Domain, error) {
-	req, err := c.newRequest("GET", "/v1/domains")
-	if err != nil {
-		return nil, err
+// values returns the form values of the parameters, leaving out the fields only sent when
+// creating a domain unless create is set.
+func (p DomainParameters) values(create bool) (url.Values, error) {
+	if !create {
+		return encodeForm(p, "create")
 	}
 
-	res, err := c.doRequest(req)
+	params, err := encodeForm(p)
 	if err != nil {
 		return nil, err
 	}
+	if len(p.CatchAllRecipients) > 0 {
+		params.Set("catchall", strings.Join(p.CatchAllRecipients, ","))
+	}
 
-	var items []Domain
+	return params, nil
+}
 
-	err = json.Unmarshal(res, &items)
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) GetDomains(opts ...RequestOption) ([]Domain, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
 
-	return items, nil
+	return c.GetDomainsContext(ctx)
 }
 
-func (c *Client) GetDomain(name string) (*Domain, error) {
-	req, err := c.newRequest("GET", fmt.Sprintf("/v1/domains/%s", name))
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) GetDomainsContext(ctx context.Context) ([]Domain, error) {
+	items, _, err := c.GetDomainsPageContext(ctx, ListDomainParameters{})
 
-	res, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
+	return items, err
+}
 
-	var item Domain
+type ListDomainParameters struct {
+	ListOptions
 
-	err = json.Unmarshal(res, &item)
-	if err != nil {
-		return nil, err
-	}
+	// Name matches the domain names containing it.
+	Name string
 
-	return &item, nil
+	// SortField and SortOrder take precedence over ListOptions.Sort when set.
+	SortField string
+	SortOrder string // "asc" or "desc"
 }
 
-func (c *Client) CreateDomain(name string, parameters DomainParameters) (*Domain, error) {
-	req, err := c.newRequest("POST", "/v1/domains")
-	if err != nil {
-		return nil, err
+func (p ListDomainParameters) values() url.Values {
+	params := p.ListOptions.values()
+	if p.Name != "" {
+		params.Add("name", p.Name)
 	}
-
-	params := url.Values{}
-	params.Add("domain", name)
-
-	for k, v := range map[string]*bool{
-		"has_adult_content_protection": parameters.HasAdultContentProtection,
-		"has_phishing_protection":      parameters.HasPhishingProtection,
-		"has_executable_protection":    parameters.HasExecutableProtection,
-		"has_virus_protection":         parameters.HasVirusProtection,
-		"has_recipient_verification":   parameters.HasRecipientVerification,
-	} {
-		if v != nil {
-			params.Add(k, strconv.FormatBool(*v))
-		}
+	if p.SortField != "" {
+		params.Set("sort", sortValue(p.SortField, p.SortOrder))
 	}
 
-	req.Body = io.NopCloser(strings.NewReader(params.Encode()))
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return params
+}
 
-	res, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
+// GetDomainsPage returns a single page of the domains matching parameters along with the
+// pagination details.
+func (c *Client) GetDomainsPage(parameters ListDomainParameters, opts ...RequestOption) ([]Domain, *Pagination, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
 
-	var item Domain
+	return c.GetDomainsPageContext(ctx, parameters)
+}
 
-	err = json.Unmarshal(res, &item)
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) GetDomainsPageContext(ctx context.Context, parameters ListDomainParameters) ([]Domain, *Pagination, error) {
+	return list[Domain](ctx, c, "/v1/domains", parameters.values())
+}
 
-	return &item, nil
+// GetAllDomains follows every page starting from parameters.Page and returns all matching domains.
+func (c *Client) GetAllDomains(parameters ListDomainParameters, opts ...RequestOption) ([]Domain, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetAllDomainsContext(ctx, parameters)
+}
+
+func (c *Client) GetAllDomainsContext(ctx context.Context, parameters ListDomainParameters) ([]Domain, error) {
+	return collectPages(parameters.Page, func(page int) ([]Domain, *Pagination, error) {
+		parameters.Page = page
+		return c.GetDomainsPageContext(ctx, parameters)
+	})
 }
 
-func (c *Client) UpdateDomain(name string, parameters DomainParameters) (*Domain, error) {
-	req, err := c.newRequest("PUT", fmt.Sprintf("/v1/domains/%s", name))
+func (c *Client) GetDomain(name string, opts ...RequestOption) (*Domain, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetDomainContext(ctx, name)
+}
+
+func (c *Client) GetDomainContext(ctx context.Context, name string) (*Domain, error) {
+	return do[Domain](ctx, c, "GET", pathf("/v1/domains/%s", name), nil)
+}
+
+// GetDomainByName returns the domain with the given fully qualified name, fetched directly
+// rather than by listing the domains of the account. The name is matched case-insensitively
+// and may end with a dot or be internationalized, see DomainToASCII. Unlike GetDomain, the
+// value can never be mistaken for a domain ID.
+func (c *Client) GetDomainByName(name string, opts ...RequestOption) (*Domain, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetDomainByNameContext(ctx, name)
+}
+
+func (c *Client) GetDomainByNameContext(ctx context.Context, name string) (*Domain, error) {
+	fqdn, err := DomainToASCII(name)
 	if err != nil {
 		return nil, err
 	}
-
-	params := url.Values{}
-	params.Add("domain", name)
-
-	for k, v := range map[string]*bool{
-		"has_adult_content_protection": parameters.HasAdultContentProtection,
-		"has_phishing_protection":      parameters.HasPhishingProtection,
-		"has_executable_protection":    parameters.HasExecutableProtection,
-		"has_virus_protection":         parameters.HasVirusProtection,
-		"has_recipient_verification":   parameters.HasRecipientVerification,
-	} {
-		if v != nil {
-			params.Add(k, strconv.FormatBool(*v))
-		}
+	if !strings.Contains(fqdn, ".") || isObjectID(fqdn) {
+		return nil, fmt.Errorf("domain name %q is not a fully qualified domain name", name)
 	}
 
-	req.Body = io.NopCloser(strings.NewReader(params.Encode()))
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.GetDomainContext(ctx, fqdn)
+}
+
+func (c *Client) CreateDomain(name string, parameters DomainParameters, opts ...RequestOption) (*Domain, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
 
-	res, err := c.doRequest(req)
+	return c.CreateDomainContext(ctx, name, parameters)
+}
+
+func (c *Client) CreateDomainContext(ctx context.Context, name string, parameters DomainParameters) (*Domain, error) {
+	params, err := parameters.values(true)
 	if err != nil {
 		return nil, err
 	}
+	params.Add("domain", domainPathSegment(name))
 
-	var item Domain
+	return do[Domain](ctx, c, "POST", "/v1/domains", params)
+}
 
-	err = json.Unmarshal(res, &item)
+func (c *Client) UpdateDomain(name string, parameters DomainParameters, opts ...RequestOption) (*Domain, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.UpdateDomainContext(ctx, name, parameters)
+}
+
+func (c *Client) UpdateDomainContext(ctx context.Context, name string, parameters DomainParameters) (*Domain, error) {
+	params, err := parameters.values(false)
 	if err != nil {
 		return nil, err
 	}
+	params.Add("domain", domainPathSegment(name))
 
-	return &item, nil
+	return do[Domain](ctx, c, "PUT", pathf("/v1/domains/%s", name), params)
 }
 
-func (c *Client) DeleteDomain(name string) error {
-	req, err := c.newRequest("DELETE", fmt.Sprintf("/v1/domains/%s", name))
+func (c *Client) DeleteDomain(name string, opts ...RequestOption) error {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.DeleteDomainContext(ctx, name)
+}
+
+func (c *Client) DeleteDomainContext(ctx context.Context, name string) error {
+	return doDiscard(ctx, c, "DELETE", pathf("/v1/domains/%s", name), nil)
+}
+
+// VerifyDomainRecords asks the API to check the forwarding DNS records (MX and TXT) of a domain.
+// It returns the confirmation message, or an *APIError describing what is missing.
+func (c *Client) VerifyDomainRecords(name string, opts ...RequestOption) (string, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.VerifyDomainRecordsContext(ctx, name)
+}
+
+func (c *Client) VerifyDomainRecordsContext(ctx context.Context, name string) (string, error) {
+	return c.verifyDomain(ctx, pathf("/v1/domains/%s/verify-records", name))
+}
+
+// VerifySMTP asks the API to check the outbound SMTP DNS records (DKIM, Return-Path and DMARC) of a domain.
+// It returns the confirmation message, or an *APIError describing what is missing.
+func (c *Client) VerifySMTP(name string, opts ...RequestOption) (string, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.VerifySMTPContext(ctx, name)
+}
+
+func (c *Client) VerifySMTPContext(ctx context.Context, name string) (string, error) {
+	return c.verifyDomain(ctx, pathf("/v1/domains/%s/verify-smtp", name))
+}
+
+func (c *Client) verifyDomain(ctx context.Context, path string) (string, error) {
+	req, err := c.newRequest(ctx, "GET", path)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	_, err = c.doRequest(req)
+	res, err := c.doRequest(req)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	var message string
+	if err := c.unmarshal(req, res, &message); err != nil {
+		return string(res), nil
 	}
 
-	return nil
+	return message, nil
 }
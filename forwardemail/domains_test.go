@@ -2,6 +2,7 @@ package forwardemail
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -58,8 +59,8 @@ func TestClient_GetDomain(t *testing.T) {
 				VerificationRecord:        "v8O0S8JjRv",
 				Id:                        "15ff615b6180f1fc7faf40e6",
 				Object:                    "domain",
-				CreatedAt:                 parseTime("2023-09-21T20:18:24.790Z"),
-				UpdatedAt:                 parseTime("2023-10-07T21:21:01.992Z"),
+				CreatedAt:                 Timestamp{parseTime("2023-09-21T20:18:24.790Z")},
+				UpdatedAt:                 Timestamp{parseTime("2023-10-07T21:21:01.992Z")},
 				Link:                      "https://forwardemail.net/my-account/domains/stark.com",
 			},
 		},
@@ -156,8 +157,8 @@ func TestClient_GetDomains(t *testing.T) {
 					VerificationRecord:        "v8O0S8JjRv",
 					Id:                        "15ff615b6180f1fc7faf40e6",
 					Object:                    "domain",
-					CreatedAt:                 parseTime("2023-09-21T20:18:24.790Z"),
-					UpdatedAt:                 parseTime("2023-10-07T21:21:01.992Z"),
+					CreatedAt:                 Timestamp{parseTime("2023-09-21T20:18:24.790Z")},
+					UpdatedAt:                 Timestamp{parseTime("2023-10-07T21:21:01.992Z")},
 					Link:                      "https://forwardemail.net/my-account/domains/stark.com",
 				},
 				{
@@ -174,8 +175,8 @@ func TestClient_GetDomains(t *testing.T) {
 					VerificationRecord:        "v0jJ88SROv",
 					Id:                        "e61ffff601c7fb14185af506",
 					Object:                    "domain",
-					CreatedAt:                 parseTime("2023-04-04T12:13:55.723Z"),
-					UpdatedAt:                 parseTime("2023-11-03T22:22:02.724Z"),
+					CreatedAt:                 Timestamp{parseTime("2023-04-04T12:13:55.723Z")},
+					UpdatedAt:                 Timestamp{parseTime("2023-11-03T22:22:02.724Z")},
 					Link:                      "https://forwardemail.net/my-account/domains/rhodes.com",
 				},
 			},
@@ -201,6 +202,97 @@ func TestClient_GetDomains(t *testing.T) {
 	}
 }
 
+func TestClient_GetDomainsPage_Parameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters ListDomainParameters
+		want       string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name: "name and paging",
+			parameters: ListDomainParameters{
+				ListOptions: ListOptions{Page: 2, Limit: 10, Sort: "created_at"},
+				Name:        "stark",
+			},
+			want: "limit=10&name=stark&page=2&sort=created_at",
+		},
+		{
+			name: "sort order",
+			parameters: ListDomainParameters{
+				ListOptions: ListOptions{Sort: "created_at"},
+				SortField:   "name",
+				SortOrder:   "desc",
+			},
+			want: "sort=-name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.RawQuery
+				fmt.Fprint(w, `[]`)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			if _, _, err := c.GetDomainsPage(tt.parameters); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, query); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_GetDomainByName(t *testing.T) {
+	tests := []struct {
+		name     string
+		domain   string
+		wantPath string
+		wantErr  bool
+	}{
+		{name: "name", domain: "stark.com", wantPath: "/v1/domains/stark.com"},
+		{name: "trailing dot and case", domain: " Stark.COM. ", wantPath: "/v1/domains/stark.com"},
+		{name: "not qualified", domain: "localhost", wantErr: true},
+		{name: "id", domain: "5e1f4b4c2c5f2e1a3c8b4567", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				fmt.Fprint(w, `{"name": "stark.com"}`)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, err := c.GetDomainByName(tt.domain)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if diff := cmp.Diff(tt.wantPath, path); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+			if !tt.wantErr && got.Name != "stark.com" {
+				t.Errorf("unexpected domain %+v", got)
+			}
+		})
+	}
+}
+
 func TestClient_CreateDomain(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -258,8 +350,8 @@ func TestClient_CreateDomain(t *testing.T) {
 				VerificationRecord:        "v8O0S8JjRv",
 				Id:                        "15ff615b6180f1fc7faf40e6",
 				Object:                    "domain",
-				CreatedAt:                 parseTime("2023-09-21T20:18:24.790Z"),
-				UpdatedAt:                 parseTime("2023-10-07T21:21:01.992Z"),
+				CreatedAt:                 Timestamp{parseTime("2023-09-21T20:18:24.790Z")},
+				UpdatedAt:                 Timestamp{parseTime("2023-10-07T21:21:01.992Z")},
 				Link:                      "https://forwardemail.net/my-account/domains/stark.com",
 			},
 		},
@@ -341,8 +433,8 @@ func TestClient_UpdateDomain(t *testing.T) {
 				VerificationRecord:        "v8O0S8JjRv",
 				Id:                        "15ff615b6180f1fc7faf40e6",
 				Object:                    "domain",
-				CreatedAt:                 parseTime("2023-09-21T20:18:24.790Z"),
-				UpdatedAt:                 parseTime("2023-10-07T21:21:01.992Z"),
+				CreatedAt:                 Timestamp{parseTime("2023-09-21T20:18:24.790Z")},
+				UpdatedAt:                 Timestamp{parseTime("2023-10-07T21:21:01.992Z")},
 				Link:                      "https://forwardemail.net/my-account/domains/stark.com",
 			},
 		},
@@ -391,7 +483,7 @@ func TestClient_DeleteDomain(t *testing.T) {
 				code: http.StatusInternalServerError,
 				body: "oh no",
 			},
-			want: fmt.Errorf("status: 500, body: oh no"),
+			want: &APIError{StatusCode: http.StatusInternalServerError, Message: "oh no", Body: []byte("oh no")},
 		},
 	}
 
@@ -427,3 +519,188 @@ func equateErrorMessage(x, y error) bool {
 func pointBool(b bool) *bool {
 	return &b
 }
+
+func TestClient_VerifyDomainRecords(t *testing.T) {
+	type response struct {
+		code int
+		body string
+	}
+
+	tests := []struct {
+		name    string
+		res     response
+		want    string
+		wantErr error
+	}{
+		{
+			name: "ok",
+			res: response{
+				code: http.StatusOK,
+				body: `"Domain's DNS records have been verified."`,
+			},
+			want: "Domain's DNS records have been verified.",
+		},
+		{
+			name: "not ok",
+			res: response{
+				code: http.StatusBadRequest,
+				body: `{"message": "Domain is missing required DNS MX records."}`,
+			},
+			wantErr: &APIError{StatusCode: http.StatusBadRequest, Message: "Domain is missing required DNS MX records.", Body: []byte(`{"message": "Domain is missing required DNS MX records."}`)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				paths = append(paths, r.URL.Path)
+
+				w.WriteHeader(tt.res.code)
+				fmt.Fprint(w, tt.res.body)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, err := c.VerifyDomainRecords("stark.com")
+			if diff := cmp.Diff(tt.wantErr, err, cmp.Comparer(equateErrorMessage)); diff != "" {
+				t.Fatalf("errors are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+
+			_, _ = c.VerifySMTP("stark.com")
+			want := []string{"/v1/domains/stark.com/verify-records", "/v1/domains/stark.com/verify-smtp"}
+			if diff := cmp.Diff(want, paths); diff != "" {
+				t.Fatalf("paths are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestDomainParameters_values(t *testing.T) {
+	tests := []struct {
+		name   string
+		params DomainParameters
+		create bool
+		want   string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "everything at once",
+			params: DomainParameters{
+				HasAdultContentProtection: pointBool(true),
+				HasPhishingProtection:     pointBool(true),
+				HasExecutableProtection:   pointBool(false),
+				HasVirusProtection:        pointBool(true),
+				HasRecipientVerification:  pointBool(false),
+				IgnoreMxCheck:             pointBool(true),
+				BounceWebhook:             pointString("https://stark.com/bounces"),
+				SmtpPort:                  pointString("2525"),
+				MaxRecipientsPerAlias:     pointInt(25),
+			},
+			want: "bounce_webhook=https%3A%2F%2Fstark.com%2Fbounces&has_adult_content_protection=true&has_executable_protection=false&has_phishing_protection=true&has_recipient_verification=false&has_virus_protection=true&ignore_mx_check=true&max_recipients_per_alias=25&smtp_port=2525",
+		},
+		{
+			name: "cleared webhook",
+			params: DomainParameters{
+				BounceWebhook: pointString(""),
+			},
+			want: "bounce_webhook=",
+		},
+		{
+			name: "create parameters on update",
+			params: DomainParameters{
+				Plan:               pointString("team"),
+				CatchAll:           pointBool(false),
+				CatchAllRecipients: []string{"tony@stark.com"},
+				TeamDomain:         pointString("stark.com"),
+			},
+		},
+		{
+			name: "catch-all disabled",
+			params: DomainParameters{
+				Plan:       pointString("team"),
+				CatchAll:   pointBool(false),
+				TeamDomain: pointString("stark.com"),
+			},
+			create: true,
+			want:   "catchall=false&plan=team&team_domain=stark.com",
+		},
+		{
+			name: "catch-all recipients win",
+			params: DomainParameters{
+				CatchAll:           pointBool(false),
+				CatchAllRecipients: []string{"tony@stark.com", "pepper@stark.com"},
+			},
+			create: true,
+			want:   "catchall=tony%40stark.com%2Cpepper%40stark.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := tt.params.values(tt.create)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, params.Encode()); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_UpdateDomain_IgnoresCreateParameters(t *testing.T) {
+	var body string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	_, err := c.UpdateDomain("stark.com", DomainParameters{
+		Plan:     pointString("team"),
+		CatchAll: pointBool(false),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("domain=stark.com", body); diff != "" {
+		t.Fatalf("request bodies are not the same %s", diff)
+	}
+}
+
+func TestClient_UpdateDomain_UnicodeName(t *testing.T) {
+	var path, body string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		path, body = r.URL.EscapedPath(), string(b)
+
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	if _, err := c.UpdateDomain("bücher.de", DomainParameters{}); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("/v1/domains/xn--bcher-kva.de domain=xn--bcher-kva.de", path+" "+body); diff != "" {
+		t.Fatalf("requests are not the same %s", diff)
+	}
+}
@@ -0,0 +1,85 @@
+package forwardemail
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// WithDryRun stops the client from sending requests that modify data. Such requests are
+// logged to the logger given to WithLogger, body included unless it carries secrets, and
+// answered locally: JSON bodies are echoed back as the result and other requests get an
+// empty object, so fields only the API would fill in are left zero, and WithStrictDecoding
+// doesn't apply to them. Read requests are still sent, so for example UpsertAlias still
+// fetches the current alias.
+func WithDryRun() Option {
+	return func(c *Client) {
+		c.dryRun = true
+	}
+}
+
+type dryRunKey struct{}
+
+// ContextWithDryRun turns on dry-run mode, as described on WithDryRun, for the calls made with ctx.
+func ContextWithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+func (c *Client) isDryRun(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+
+	dryRun, _ := req.Context().Value(dryRunKey{}).(bool)
+
+	return c.dryRun || dryRun
+}
+
+func (c *Client) dryRunResponse(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.logger != nil {
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("path", req.URL.EscapedPath()),
+		}
+		if req.URL.RawQuery != "" {
+			attrs = append(attrs, slog.String("query", redactQuery(req.URL.Query()).Encode()))
+		}
+		if HasSecrets(req.Context()) {
+			attrs = append(attrs, slog.String("body", redacted))
+		} else if len(body) > 0 {
+			attrs = append(attrs, slog.String("body", string(body)))
+		}
+		c.logger.LogAttrs(req.Context(), c.logLevel, "forwardemail dry run", attrs...)
+	}
+
+	result := []byte("{}")
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") && len(body) > 0 {
+		result = body
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(result)),
+		ContentLength: int64(len(result)),
+		Request:       req,
+	}, nil
+}
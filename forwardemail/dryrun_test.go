@@ -0,0 +1,122 @@
+package forwardemail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_WithDryRun(t *testing.T) {
+	var calls []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+
+		fmt.Fprint(w, `{"name": "tony", "recipients": ["tony@stark.com"], "is_enabled": true}`)
+	}))
+	defer svr.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == "latency" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	}, WithDryRun(), WithLogger(logger))
+
+	created, err := c.CreateAlias("stark.com", "pepper", AliasParameters{
+		Recipients: pointSliceOfStrings([]string{"pepper@stark.com"}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"pepper", "pepper@stark.com"}, []string{created.Name, created.Recipients[0]}); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	if _, err := c.UpdateAlias("stark.com", "tony", AliasParameters{Description: "main email"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteAlias("stark.com", "tony"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GenerateAliasPassword("stark.com", "tony", GeneratePasswordParameters{NewPassword: pointString("s3cr3t")}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(calls) != 0 {
+		t.Fatalf("no request should reach the API, got %v", calls)
+	}
+
+	var dryRuns []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if strings.Contains(line, "dry run") {
+			dryRuns = append(dryRuns, line)
+		}
+	}
+	want := []string{
+		`level=DEBUG msg="forwardemail dry run" method=POST path=/v1/domains/stark.com/aliases body="{\"name\":\"pepper\",\"recipients\":[\"pepper@stark.com\"]}"`,
+		`level=DEBUG msg="forwardemail dry run" method=PUT path=/v1/domains/stark.com/aliases/tony body="{\"description\":\"main email\"}"`,
+		`level=DEBUG msg="forwardemail dry run" method=DELETE path=/v1/domains/stark.com/aliases/tony`,
+		`level=DEBUG msg="forwardemail dry run" method=POST path=/v1/domains/stark.com/aliases/tony/generate-password body=[REDACTED]`,
+	}
+	if diff := cmp.Diff(want, dryRuns); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestContextWithDryRun(t *testing.T) {
+	var calls int
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	if err := c.DeleteAliasContext(ContextWithDryRun(context.Background()), "stark.com", "tony"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 0 {
+		t.Fatalf("expected no calls, got %d", calls)
+	}
+
+	if err := c.DeleteAlias("stark.com", "tony"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestClient_WithDryRun_StrictDecoding(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	}, WithDryRun(), WithStrictDecoding())
+
+	if _, err := c.UpdateAlias("stark.com", "tony", AliasParameters{VacationResponderIsEnabled: pointBool(true)}); err != nil {
+		t.Fatal(err)
+	}
+}
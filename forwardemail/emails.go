@@ -0,0 +1,259 @@
+package forwardemail
+
+import (
+	"context"
+	"io"
+)
+
+type Envelope struct {
+	From string   `json:"from"`
+	To   []string `json:"to"`
+}
+
+type Email struct {
+	Alias       string      `json:"alias"`
+	Domain      DomainOrID  `json:"domain"`
+	User        AccountOrID `json:"user"`
+	Status      string      `json:"status"`
+	IsRedacted  bool        `json:"is_redacted"`
+	Envelope    Envelope    `json:"envelope"`
+	MessageId   string      `json:"messageId"`
+	Date        Timestamp   `json:"date"`
+	Subject     string      `json:"subject"`
+	Accepted    []string    `json:"accepted"`
+	HardBounces []string    `json:"hard_bounces"`
+	SoftBounces []string    `json:"soft_bounces"`
+	Id          string      `json:"id"`
+	Object      string      `json:"object"`
+	CreatedAt   Timestamp   `json:"created_at"`
+	UpdatedAt   Timestamp   `json:"updated_at"`
+	Link        string      `json:"link"`
+}
+
+// Attachment follows the Nodemailer attachment format accepted by the API.
+// Content is expected to be base64 encoded when Encoding is "base64".
+type Attachment struct {
+	Filename    string `json:"filename,omitempty"`
+	Content     string `json:"content,omitempty"`
+	Encoding    string `json:"encoding,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Cid         string `json:"cid,omitempty"`
+
+	// Reader, when set, replaces Content: it is read and base64 encoded as the request is
+	// sent. See NewAttachment. Emails with such attachments are never retried.
+	Reader io.Reader `json:"-"`
+}
+
+// EmailParameters are sent as a JSON body since attachments can't be expressed as form fields.
+type EmailParameters struct {
+	From        string       `json:"from"`
+	To          []string     `json:"to,omitempty"`
+	Cc          []string     `json:"cc,omitempty"`
+	Bcc         []string     `json:"bcc,omitempty"`
+	ReplyTo     string       `json:"replyTo,omitempty"`
+	Subject     string       `json:"subject,omitempty"`
+	Text        string       `json:"text,omitempty"`
+	Html        string       `json:"html,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+type EmailLimit struct {
+	Count int `json:"count"`
+	Limit int `json:"limit"`
+}
+
+func (c *Client) GetEmails(opts ...RequestOption) ([]Email, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetEmailsContext(ctx)
+}
+
+func (c *Client) GetEmailsContext(ctx context.Context) ([]Email, error) {
+	items, _, err := c.GetEmailsPageContext(ctx, ListOptions{})
+
+	return items, err
+}
+
+// GetEmailsPage returns a single page of outbound emails along with the pagination details.
+func (c *Client) GetEmailsPage(options ListOptions, opts ...RequestOption) ([]Email, *Pagination, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetEmailsPageContext(ctx, options)
+}
+
+func (c *Client) GetEmailsPageContext(ctx context.Context, options ListOptions) ([]Email, *Pagination, error) {
+	if err := c.requireOutboundSMTP(); err != nil {
+		return nil, nil, err
+	}
+
+	path := "/v1/emails"
+	if query := options.values().Encode(); query != "" {
+		path += "?" + query
+	}
+
+	req, err := c.newRequest(ctx, "GET", path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, header, err := c.doRequestWithHeader(req)
+	if err != nil {
+		return nil, nil, outboundSMTPError(err)
+	}
+
+	var items []Email
+
+	err = c.unmarshal(req, res, &items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return items, parsePagination(header), nil
+}
+
+func (c *Client) GetEmail(id string, opts ...RequestOption) (*Email, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetEmailContext(ctx, id)
+}
+
+func (c *Client) GetEmailContext(ctx context.Context, id string) (*Email, error) {
+	if err := c.requireOutboundSMTP(); err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, "GET", pathf("/v1/emails/%s", id))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var item Email
+
+	err = c.unmarshal(req, res, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// CreateEmail queues an outbound email for delivery through Forward Email's SMTP.
+// Like the other email methods, it returns ErrFeatureUnavailable on instances without
+// outbound SMTP, see DetectFeatures.
+func (c *Client) CreateEmail(parameters EmailParameters, opts ...RequestOption) (*Email, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.CreateEmailContext(ctx, parameters)
+}
+
+func (c *Client) CreateEmailContext(ctx context.Context, parameters EmailParameters) (*Email, error) {
+	if err := c.requireOutboundSMTP(); err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, "POST", "/v1/emails")
+	if err != nil {
+		return nil, err
+	}
+
+	if parameters.hasStreamingAttachments() {
+		req.Body = streamEmailBody(ctx, parameters)
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		err = setJSONBody(req, parameters)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, outboundSMTPError(err)
+	}
+
+	var item Email
+
+	err = c.unmarshal(req, res, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) DeleteEmail(id string, opts ...RequestOption) error {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.DeleteEmailContext(ctx, id)
+}
+
+func (c *Client) DeleteEmailContext(ctx context.Context, id string) error {
+	if err := c.requireOutboundSMTP(); err != nil {
+		return err
+	}
+
+	req, err := c.newRequest(ctx, "DELETE", pathf("/v1/emails/%s", id))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetEmailLimit returns how many emails were sent today against the daily sending limit.
+func (c *Client) GetEmailLimit(opts ...RequestOption) (*EmailLimit, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetEmailLimitContext(ctx)
+}
+
+func (c *Client) GetEmailLimitContext(ctx context.Context) (*EmailLimit, error) {
+	if err := c.requireOutboundSMTP(); err != nil {
+		return nil, err
+	}
+
+	limit, err := c.getEmailLimit(ctx)
+	if err != nil {
+		return nil, outboundSMTPError(err)
+	}
+
+	return limit, nil
+}
+
+// getEmailLimit is GetEmailLimit without the feature check, for DetectFeatures.
+func (c *Client) getEmailLimit(ctx context.Context) (*EmailLimit, error) {
+	req, err := c.newRequest(ctx, "GET", "/v1/emails/limit")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var item EmailLimit
+
+	err = c.unmarshal(req, res, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
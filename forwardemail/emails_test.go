@@ -0,0 +1,249 @@
+package forwardemail
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const emailResponse = `{
+	"alias": "6525b03e0bde8f333ace5824",
+	"domain": "15ff615b6180f1fc7faf40e6",
+	"user": "59ad551ae6fb4a4c53427ca38079f029",
+	"status": "queued",
+	"is_redacted": false,
+	"envelope": {
+	  "from": "tony@stark.com",
+	  "to": ["james@rhodes.com"]
+	},
+	"messageId": "<a1b2c3@stark.com>",
+	"date": "2023-10-10T20:12:46.588Z",
+	"subject": "Suit up",
+	"accepted": [],
+	"id": "65c1e1d1a2b3c4d5e6f7a8b9",
+	"object": "email",
+	"created_at": "2023-10-10T20:12:46.588Z",
+	"updated_at": "2023-10-10T20:12:46.588Z",
+	"link": "https://forwardemail.net/my-account/emails/65c1e1d1a2b3c4d5e6f7a8b9"
+}`
+
+var emailWant = &Email{
+	Alias:  "6525b03e0bde8f333ace5824",
+	Domain: DomainOrID{ID: "15ff615b6180f1fc7faf40e6"},
+	User:   AccountOrID{ID: "59ad551ae6fb4a4c53427ca38079f029"},
+	Status: "queued",
+	Envelope: Envelope{
+		From: "tony@stark.com",
+		To:   []string{"james@rhodes.com"},
+	},
+	MessageId: "<a1b2c3@stark.com>",
+	Date:      Timestamp{parseTime("2023-10-10T20:12:46.588Z")},
+	Subject:   "Suit up",
+	Accepted:  []string{},
+	Id:        "65c1e1d1a2b3c4d5e6f7a8b9",
+	Object:    "email",
+	CreatedAt: Timestamp{parseTime("2023-10-10T20:12:46.588Z")},
+	UpdatedAt: Timestamp{parseTime("2023-10-10T20:12:46.588Z")},
+	Link:      "https://forwardemail.net/my-account/emails/65c1e1d1a2b3c4d5e6f7a8b9",
+}
+
+func TestClient_GetEmails(t *testing.T) {
+	tests := []struct {
+		name string
+		res  string
+		want []Email
+	}{
+		{
+			name: "no data",
+		},
+		{
+			name: "ok",
+			res:  "[" + emailResponse + "]",
+			want: []Email{*emailWant},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.res)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, _ := c.GetEmails()
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_GetEmail(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		res  string
+		want *Email
+	}{
+		{
+			name: "no data",
+		},
+		{
+			name: "ok",
+			id:   "65c1e1d1a2b3c4d5e6f7a8b9",
+			res:  emailResponse,
+			want: emailWant,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.res)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, _ := c.GetEmail(tt.id)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_CreateEmail(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   EmailParameters
+		res      string
+		wantBody string
+		want     *Email
+	}{
+		{
+			name:     "no data",
+			wantBody: `{"from":""}`,
+		},
+		{
+			name: "ok",
+			params: EmailParameters{
+				From:    "tony@stark.com",
+				To:      []string{"james@rhodes.com"},
+				Subject: "Suit up",
+				Text:    "Meet me at the tower.",
+				Attachments: []Attachment{
+					{
+						Filename:    "plans.txt",
+						Content:     "TWFyayBJ",
+						Encoding:    "base64",
+						ContentType: "text/plain",
+					},
+				},
+			},
+			res:      emailResponse,
+			wantBody: `{"from":"tony@stark.com","to":["james@rhodes.com"],"subject":"Suit up","text":"Meet me at the tower.","attachments":[{"filename":"plans.txt","content":"TWFyayBJ","encoding":"base64","contentType":"text/plain"}]}`,
+			want:     emailWant,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				body = string(b)
+
+				fmt.Fprint(w, tt.res)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, _ := c.CreateEmail(tt.params)
+			if diff := cmp.Diff(tt.wantBody, body); diff != "" {
+				t.Fatalf("request bodies are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_DeleteEmail(t *testing.T) {
+	type response struct {
+		code int
+		body string
+	}
+
+	tests := []struct {
+		name string
+		id   string
+		res  response
+		want error
+	}{
+		{
+			name: "ok",
+			id:   "65c1e1d1a2b3c4d5e6f7a8b9",
+			res: response{
+				code: http.StatusOK,
+			},
+		},
+		{
+			name: "not ok",
+			res: response{
+				code: http.StatusInternalServerError,
+				body: "oh no",
+			},
+			want: &APIError{StatusCode: http.StatusInternalServerError, Message: "oh no", Body: []byte("oh no")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.res.code)
+				fmt.Fprint(w, tt.res.body)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got := c.DeleteEmail(tt.id)
+			if diff := cmp.Diff(tt.want, got, cmp.Comparer(equateErrorMessage)); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_GetEmailLimit(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count": 12, "limit": 300}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	got, _ := c.GetEmailLimit()
+	if diff := cmp.Diff(&EmailLimit{Count: 12, Limit: 300}, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
@@ -0,0 +1,40 @@
+package forwardemail
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// EncryptTXT encrypts a plaintext forwarding TXT record value (e.g. "forward-email=tony@stark.com")
+// so it can be published in DNS without revealing the recipients.
+func (c *Client) EncryptTXT(input string, opts ...RequestOption) (string, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.EncryptTXTContext(ctx, input)
+}
+
+func (c *Client) EncryptTXTContext(ctx context.Context, input string) (string, error) {
+	req, err := c.newRequest(ctx, "POST", "/v1/encrypt")
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Add("input", input)
+
+	setFormBody(req, params)
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	var encrypted string
+	if err := c.unmarshal(req, res, &encrypted); err != nil {
+		return strings.TrimSpace(string(res)), nil
+	}
+
+	return encrypted, nil
+}
@@ -0,0 +1,61 @@
+package forwardemail
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_EncryptTXT(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		res      string
+		wantBody string
+		want     string
+	}{
+		{
+			name:     "plain text response",
+			input:    "forward-email=tony@stark.com",
+			res:      "forward-email=a1b2c3d4e5f6\n",
+			wantBody: "input=forward-email%3Dtony%40stark.com",
+			want:     "forward-email=a1b2c3d4e5f6",
+		},
+		{
+			name:     "json response",
+			input:    "forward-email=tony@stark.com",
+			res:      `"forward-email=a1b2c3d4e5f6"`,
+			wantBody: "input=forward-email%3Dtony%40stark.com",
+			want:     "forward-email=a1b2c3d4e5f6",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				body = string(b)
+
+				fmt.Fprint(w, tt.res)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, _ := c.EncryptTXT(tt.input)
+			if diff := cmp.Diff(tt.wantBody, body); diff != "" {
+				t.Fatalf("request bodies are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
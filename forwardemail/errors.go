@@ -0,0 +1,177 @@
+package forwardemail
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// APIError is returned when the API responds with a non-successful status code.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Body       []byte
+
+	// Reason is the HTTP reason of the JSON error payload, e.g. "Bad Request".
+	Reason string
+	// Fields lists the field-level problems of a validation error, when the API detailed them.
+	// They are also available as a *ValidationError through errors.As.
+	Fields []FieldError
+	// RequestID identifies the failed request to Forward Email's support.
+	RequestID string
+
+	// RetryAfter is how long the API asked us to wait before retrying, parsed
+	// from the Retry-After header. It is zero when the header is absent.
+	RetryAfter time.Duration
+}
+
+// Error describes the error with the message of the API, or with the raw body when the API
+// didn't send one.
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("status: %d, message: %s", e.StatusCode, e.Message)
+	}
+
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// Unwrap returns the field-level problems as a *ValidationError, so that client-side and
+// API validation errors can be handled the same way.
+func (e *APIError) Unwrap() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Problems: e.Fields}
+}
+
+// newAPIError builds the *APIError of a response, with its RetryAfter measured on the client clock.
+func (c *Client) newAPIError(res *http.Response, body []byte) *APIError {
+	var payload struct {
+		Error  string          `json:"error"`
+		Errors json.RawMessage `json:"errors"`
+	}
+	_ = json.Unmarshal(body, &payload)
+
+	return &APIError{
+		StatusCode: res.StatusCode,
+		Message:    parseErrorMessage(body),
+		Body:       body,
+		Reason:     payload.Error,
+		Fields:     parseFieldErrors(payload.Errors),
+		RequestID:  res.Header.Get(RequestIDHeader),
+		RetryAfter: parseRetryAfter(res.Header.Get("Retry-After"), c.clock.Now()),
+	}
+}
+
+// IsNotFound reports whether err is an API error with a 404 status code.
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsRateLimited reports whether err is an API error with a 429 status code.
+func IsRateLimited(err error) bool {
+	return hasStatusCode(err, http.StatusTooManyRequests)
+}
+
+// IsUnauthorized reports whether err is an API error with a 401 status code.
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+func hasStatusCode(err error, code int) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == code
+	}
+
+	return false
+}
+
+// parseErrorMessage extracts the message from the JSON error payload, falling back to the raw body.
+func parseErrorMessage(body []byte) string {
+	var payload struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Message != "" {
+		return payload.Message
+	}
+
+	return string(body)
+}
+
+// parseFieldErrors supports the field errors either as an object keyed by field, whose values
+// are a message or an object with a message, or as a list of objects naming their field.
+func parseFieldErrors(data json.RawMessage) []FieldError {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var list []struct {
+		Field   string `json:"field"`
+		Path    string `json:"path"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(data, &list); err == nil {
+		var fields []FieldError
+		for _, item := range list {
+			field := item.Field
+			if field == "" {
+				field = item.Path
+			}
+			fields = append(fields, FieldError{Field: field, Message: item.Message})
+		}
+
+		return fields
+	}
+
+	var byField map[string]json.RawMessage
+	if err := json.Unmarshal(data, &byField); err != nil {
+		return nil
+	}
+
+	var fields []FieldError
+	for field, value := range byField {
+		var message string
+		if err := json.Unmarshal(value, &message); err != nil {
+			var detail struct {
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(value, &detail); err != nil {
+				continue
+			}
+			message = detail.Message
+		}
+		fields = append(fields, FieldError{Field: field, Message: message})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Field < fields[j].Field })
+
+	return fields
+}
+
+// parseRetryAfter supports both the delay-seconds and HTTP-date forms of the header.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if d := date.Sub(now); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
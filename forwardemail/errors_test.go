@@ -0,0 +1,281 @@
+package forwardemail
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := parseTime("2023-10-10T20:12:46Z")
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "seconds",
+			value: "120",
+			want:  2 * time.Minute,
+		},
+		{
+			name:  "negative seconds",
+			value: "-5",
+		},
+		{
+			name:  "http date",
+			value: "Tue, 10 Oct 2023 20:13:16 GMT",
+			want:  30 * time.Second,
+		},
+		{
+			name:  "http date in the past",
+			value: "Tue, 10 Oct 2023 20:00:00 GMT",
+		},
+		{
+			name:  "garbage",
+			value: "soon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseRetryAfter(tt.value, now)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprintf(w, "slow down")
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	_, err := c.GetAccount()
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *APIError, got %T", err)
+	}
+
+	want := &APIError{
+		StatusCode: http.StatusTooManyRequests,
+		Message:    "slow down",
+		Body:       []byte("slow down"),
+		RetryAfter: 3 * time.Second,
+	}
+	if diff := cmp.Diff(want, apiErr); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestClient_APIError_RetryAfterDate(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "Tue, 10 Oct 2023 20:13:46 GMT")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	}, WithClock(&sleepRecorder{now: parseTime("2023-10-10T20:12:46Z")}))
+
+	_, err := c.GetAccount()
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *APIError, got %T", err)
+	}
+	if diff := cmp.Diff(time.Minute, apiErr.RetryAfter); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestAPIError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *APIError
+		want string
+	}{
+		{
+			name: "message",
+			err:  &APIError{StatusCode: http.StatusNotFound, Message: "Alias does not exist.", Body: []byte(`{"message": "Alias does not exist."}`)},
+			want: "status: 404, message: Alias does not exist.",
+		},
+		{
+			name: "body only",
+			err:  &APIError{StatusCode: http.StatusBadGateway, Body: []byte("bad gateway")},
+			want: "status: 502, body: bad gateway",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.err.Error()); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "plain text",
+			body: "oh no",
+			want: "oh no",
+		},
+		{
+			name: "json payload",
+			body: `{"statusCode": 404, "error": "Not Found", "message": "Alias does not exist."}`,
+			want: "Alias does not exist.",
+		},
+		{
+			name: "json without message",
+			body: `{"statusCode": 500}`,
+			want: `{"statusCode": 500}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseErrorMessage([]byte(tt.body))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestErrorHelpers(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		notFound       bool
+		rateLimited    bool
+		isUnauthorized bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "not an api error",
+			err:  errors.New("connection refused"),
+		},
+		{
+			name:     "not found",
+			err:      &APIError{StatusCode: http.StatusNotFound},
+			notFound: true,
+		},
+		{
+			name:        "rate limited",
+			err:         fmt.Errorf("wrapped: %w", &APIError{StatusCode: http.StatusTooManyRequests}),
+			rateLimited: true,
+		},
+		{
+			name:           "unauthorized",
+			err:            &APIError{StatusCode: http.StatusUnauthorized},
+			isUnauthorized: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []bool{IsNotFound(tt.err), IsRateLimited(tt.err), IsUnauthorized(tt.err)}
+			want := []bool{tt.notFound, tt.rateLimited, tt.isUnauthorized}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_APIError_Fields(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []FieldError
+	}{
+		{
+			name: "message only",
+			body: `{"statusCode": 400, "error": "Bad Request", "message": "Invalid recipients."}`,
+		},
+		{
+			name: "object of messages",
+			body: `{"statusCode": 400, "error": "Bad Request", "message": "Validation failed.", "errors": {"recipients": "Invalid recipients.", "labels": {"message": "Label is too long."}}}`,
+			want: []FieldError{
+				{Field: "labels", Message: "Label is too long."},
+				{Field: "recipients", Message: "Invalid recipients."},
+			},
+		},
+		{
+			name: "list of fields",
+			body: `{"statusCode": 400, "error": "Bad Request", "message": "Validation failed.", "errors": [{"field": "name", "message": "Name is required."}, {"path": "recipients", "message": "Invalid recipients."}]}`,
+			want: []FieldError{
+				{Field: "name", Message: "Name is required."},
+				{Field: "recipients", Message: "Invalid recipients."},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			_, err := c.GetAccount()
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected an *APIError, got %T", err)
+			}
+			if apiErr.Reason != "Bad Request" {
+				t.Errorf("unexpected reason %q", apiErr.Reason)
+			}
+			if diff := cmp.Diff(tt.want, apiErr.Fields); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) != (tt.want != nil) {
+				t.Fatalf("unexpected *ValidationError %v", validationErr)
+			}
+			if validationErr != nil {
+				if diff := cmp.Diff(tt.want, validationErr.Problems); diff != "" {
+					t.Errorf("values are not the same %s", diff)
+				}
+			}
+		})
+	}
+}
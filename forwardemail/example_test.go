@@ -0,0 +1,133 @@
+package forwardemail_test
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/mattwebbio/go-forwardemail/forwardemail"
+	"github.com/mattwebbio/go-forwardemail/forwardemail/forwardemailtest"
+)
+
+func ExampleClient_GetDomains() {
+	// The fake server stands in for the API; use
+	// forwardemail.NewClient(forwardemail.ClientOptions{ApiKey: "..."}) in real code.
+	svr := forwardemailtest.NewServer()
+	defer svr.Close()
+	svr.HandleJSON("GET", "/v1/domains", http.StatusOK, []map[string]any{
+		{"name": "stark.com", "plan": "team", "has_mx_record": true},
+		{"name": "wayne.com", "plan": "free"},
+	})
+	client := svr.Client()
+
+	domains, err := client.GetDomains()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, domain := range domains {
+		fmt.Println(domain.Name, domain.Plan, domain.HasMxRecord)
+	}
+	// Output:
+	// stark.com team true
+	// wayne.com free false
+}
+
+func ExampleClient_CreateAlias() {
+	svr := forwardemailtest.NewServer()
+	defer svr.Close()
+	svr.HandleJSON("POST", "/v1/domains/stark.com/aliases", http.StatusOK, map[string]any{
+		"name":       "tony",
+		"recipients": []string{"tony@gmail.com"},
+		"labels":     []string{"founders"},
+		"is_enabled": true,
+	})
+	client := svr.Client()
+
+	recipients := []string{"tony@gmail.com"}
+	labels := []string{"founders"}
+	alias, err := client.CreateAlias("stark.com", "tony", forwardemail.AliasParameters{
+		Recipients: &recipients,
+		Labels:     &labels,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(alias.Name, alias.Recipients, alias.IsEnabled)
+	fmt.Println(svr.Requests()[0].Method, svr.Requests()[0].Path)
+	// Output:
+	// tony [tony@gmail.com] true
+	// POST /v1/domains/stark.com/aliases
+}
+
+func ExampleClient_CreateAlias_validation() {
+	client := forwardemail.NewClient(forwardemail.ClientOptions{ApiKey: "key"})
+
+	// Invalid parameters are rejected before anything is sent.
+	recipients := []string{"tony@gmail.com", "not a recipient"}
+	_, err := client.CreateAlias("stark.com", "tony", forwardemail.AliasParameters{Recipients: &recipients})
+
+	var validationErr *forwardemail.ValidationError
+	if errors.As(err, &validationErr) {
+		for _, problem := range validationErr.Problems {
+			fmt.Println(problem.Field)
+		}
+	}
+	// Output:
+	// recipients[1]
+}
+
+func ExampleRetryPolicy() {
+	// A server failing the first request to show the retry.
+	calls := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"name": "stark.com"}`)
+	}))
+	defer svr.Close()
+
+	client := forwardemail.NewClient(forwardemail.ClientOptions{
+		ApiKey: "key",
+		ApiUrl: svr.URL,
+		// Up to 4 attempts, waiting from 10ms to 50ms between them, or as long as the
+		// Retry-After header asks. Failed requests are only retried when idempotent,
+		// rate-limited ones whatever the method.
+		RetryPolicy: &forwardemail.RetryPolicy{
+			MaxAttempts: 4,
+			MinBackoff:  10 * time.Millisecond,
+			MaxBackoff:  50 * time.Millisecond,
+			Jitter:      true,
+		},
+	})
+
+	domain, err := client.GetDomain("stark.com")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(domain.Name, "after", calls, "attempts")
+	// Output:
+	// stark.com after 2 attempts
+}
+
+func ExampleIsNotFound() {
+	svr := forwardemailtest.NewServer()
+	defer svr.Close()
+	client := svr.Client()
+
+	// The fake server answers 404 to the routes it has no response for.
+	_, err := client.GetAlias("stark.com", "happy")
+	if forwardemail.IsNotFound(err) {
+		fmt.Println("no such alias")
+	}
+	// Output:
+	// no such alias
+}
@@ -0,0 +1,114 @@
+package forwardemail
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// encodeForm returns the form values of v, a struct of parameters, from the form tags of
+// its fields: `form:"name"` followed by options among:
+//
+//   - omitempty leaves out the zero values of fields that aren't pointers;
+//   - comma joins slices into a single comma-separated value instead of repeating the name;
+//   - any other option, such as create, marks the field to leave out when listed in skip.
+//
+// Nil pointers and empty slices are always left out and fields tagged "-" are ignored.
+// Supported types are strings, booleans, integers, string slices and pointers to those: an
+// exported field without a form tag or of another type is an error, so that a new field
+// can't silently be left out of the requests.
+func encodeForm(v any, skip ...string) (url.Values, error) {
+	params := url.Values{}
+
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag, ok := field.Tag.Lookup("form")
+		if !ok {
+			return nil, fmt.Errorf("forwardemail: form field %s.%s has no form tag", rt.Name(), field.Name)
+		}
+		if tag == "-" {
+			continue
+		}
+		if !supportedFormType(field.Type) {
+			return nil, fmt.Errorf("forwardemail: unsupported form field %s.%s of type %s", rt.Name(), field.Name, field.Type)
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		opts := strings.Split(options, ",")
+		if hasFormOption(opts, skip...) {
+			continue
+		}
+
+		value := rv.Field(i)
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				continue
+			}
+			value = value.Elem()
+		} else if hasFormOption(opts, "omitempty") && value.IsZero() {
+			continue
+		}
+
+		switch value.Kind() {
+		case reflect.String:
+			params.Add(name, value.String())
+		case reflect.Bool:
+			params.Add(name, strconv.FormatBool(value.Bool()))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			params.Add(name, strconv.FormatInt(value.Int(), 10))
+		case reflect.Slice:
+			values := make([]string, value.Len())
+			for i := range values {
+				values[i] = value.Index(i).String()
+			}
+			if len(values) == 0 {
+				continue
+			}
+			if hasFormOption(opts, "comma") {
+				params.Add(name, strings.Join(values, ","))
+				continue
+			}
+			for _, v := range values {
+				params.Add(name, v)
+			}
+		}
+	}
+
+	return params, nil
+}
+
+// supportedFormType reports whether encodeForm knows how to encode fields of type t, which
+// is checked on the type so that nil pointers and empty slices are checked too.
+func supportedFormType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.String
+	}
+
+	return false
+}
+
+func hasFormOption(options []string, names ...string) bool {
+	for _, option := range options {
+		for _, name := range names {
+			if option == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,128 @@
+package forwardemail
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEncodeForm(t *testing.T) {
+	type parameters struct {
+		Name     string   `form:"name"`
+		Note     string   `form:"note,omitempty"`
+		Enabled  *bool    `form:"is_enabled"`
+		Count    *int     `form:"count"`
+		Limit    int      `form:"limit,omitempty"`
+		Labels   []string `form:"labels,comma"`
+		Emails   []string `form:"emails"`
+		Plan     *string  `form:"plan,create"`
+		Internal string   `form:"-"`
+		internal string
+	}
+
+	tests := []struct {
+		name   string
+		params parameters
+		skip   []string
+		want   string
+	}{
+		{
+			name: "empty",
+			want: "name=",
+		},
+		{
+			name: "everything at once",
+			params: parameters{
+				Name:     "tony",
+				Note:     "iron man",
+				Enabled:  pointBool(false),
+				Count:    pointInt(0),
+				Limit:    3,
+				Labels:   []string{"avengers", "stark"},
+				Emails:   []string{"tony@stark.com", "pepper@stark.com"},
+				Plan:     pointString("team"),
+				Internal: "secret",
+				internal: "secret",
+			},
+			want: "count=0&emails=tony%40stark.com&emails=pepper%40stark.com&is_enabled=false&labels=avengers%2Cstark&limit=3&name=tony&note=iron+man&plan=team",
+		},
+		{
+			name: "skipped option",
+			params: parameters{
+				Name: "tony",
+				Plan: pointString("team"),
+			},
+			skip: []string{"create"},
+			want: "name=tony",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeForm(tt.params, tt.skip...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got.Encode()); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestEncodeForm_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		params any
+		want   string
+	}{
+		{
+			name: "untagged",
+			params: struct {
+				Name string
+			}{},
+			want: "forwardemail: form field .Name has no form tag",
+		},
+		{
+			name: "unsupported type",
+			params: struct {
+				Ratio float64 `form:"ratio"`
+			}{},
+			want: "forwardemail: unsupported form field .Ratio of type float64",
+		},
+		{
+			name: "unsupported slice",
+			params: struct {
+				Counts *[]int `form:"counts"`
+			}{},
+			want: "forwardemail: unsupported form field .Counts of type *[]int",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := encodeForm(tt.params)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if diff := cmp.Diff(tt.want, err.Error()); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+// Every parameter sent as a form must encode, so that a new field can't silently be left
+// out of the requests nor fail them.
+func TestFormParameters(t *testing.T) {
+	for _, v := range []any{
+		DomainParameters{},
+		AccountParameters{},
+		GeneratePasswordParameters{},
+		CatchAllPasswordParameters{},
+	} {
+		if _, err := encodeForm(v); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package forwardemailtest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mattwebbio/go-forwardemail/forwardemail"
+)
+
+// Clock is a forwardemail.Clock whose time only moves when Advance is called, for testing
+// retries, polling and cache expiry without waiting. Pass it to forwardemail.WithClock.
+type Clock struct {
+	mu       sync.Mutex
+	now      time.Time
+	sleepers []*sleeper
+	// autoAdvance makes Sleep move the time forward by itself instead of blocking.
+	autoAdvance bool
+}
+
+type sleeper struct {
+	until time.Time
+	done  chan struct{}
+}
+
+var _ forwardemail.Clock = (*Clock)(nil)
+
+// NewClock returns a Clock set at now. Sleep blocks until Advance moves the time past the
+// end of the sleep.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// NewAutoClock returns a Clock set at now where Sleep returns right away after moving the
+// time forward by the duration slept, so code sleeping on it runs through without a
+// goroutine calling Advance.
+func NewAutoClock(now time.Time) *Clock {
+	return &Clock{now: now, autoAdvance: true}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *Clock) Sleep(ctx context.Context, d time.Duration) error {
+	if c.autoAdvance {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		c.Advance(d)
+
+		return nil
+	}
+
+	c.mu.Lock()
+	if d <= 0 {
+		c.mu.Unlock()
+		return ctx.Err()
+	}
+	s := &sleeper{until: c.now.Add(d), done: make(chan struct{})}
+	c.sleepers = append(c.sleepers, s)
+	c.mu.Unlock()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		c.mu.Lock()
+		c.removeSleeper(s)
+		c.mu.Unlock()
+
+		return ctx.Err()
+	}
+}
+
+// Advance moves the time forward by d, waking up the sleeps that are over.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	remaining := c.sleepers[:0]
+	for _, s := range c.sleepers {
+		if c.now.Before(s.until) {
+			remaining = append(remaining, s)
+			continue
+		}
+		close(s.done)
+	}
+	c.sleepers = remaining
+}
+
+// Sleepers returns the number of calls to Sleep currently blocked, so a test can wait for
+// the code under test to be sleeping before calling Advance.
+func (c *Clock) Sleepers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.sleepers)
+}
+
+func (c *Clock) removeSleeper(s *sleeper) {
+	for i, other := range c.sleepers {
+		if other == s {
+			c.sleepers = append(c.sleepers[:i], c.sleepers[i+1:]...)
+			return
+		}
+	}
+}
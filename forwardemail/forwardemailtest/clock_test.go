@@ -0,0 +1,68 @@
+package forwardemailtest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mattwebbio/go-forwardemail/forwardemail"
+)
+
+func TestClock_Retries(t *testing.T) {
+	svr := NewServer()
+	defer svr.Close()
+
+	svr.HandleJSON("GET", "/v1/domains/stark.com", http.StatusServiceUnavailable, map[string]any{
+		"message": "Service Unavailable",
+	})
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewAutoClock(start)
+	c := svr.Client(forwardemail.WithClock(clock))
+	c.RetryPolicy = &forwardemail.RetryPolicy{MaxAttempts: 4, MinBackoff: time.Minute, MaxBackoff: time.Hour}
+
+	if _, err := c.GetDomain("stark.com"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if got := len(svr.Requests()); got != 4 {
+		t.Errorf("expected 4 attempts, got %d", got)
+	}
+	if got := clock.Now().Sub(start); got != 7*time.Minute {
+		t.Errorf("expected the backoffs to add up to 7m, got %v", got)
+	}
+}
+
+func TestClock_Advance(t *testing.T) {
+	clock := NewClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	done := make(chan error)
+	go func() {
+		done <- clock.Sleep(context.Background(), time.Minute)
+	}()
+
+	for clock.Sleepers() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	clock.Advance(30 * time.Second)
+	select {
+	case <-done:
+		t.Fatal("sleep ended too early")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.Advance(30 * time.Second)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := clock.Sleep(ctx, time.Minute); err != context.Canceled {
+		t.Errorf("expected the context error, got %v", err)
+	}
+	if clock.Sleepers() != 0 {
+		t.Errorf("expected no sleepers left, got %d", clock.Sleepers())
+	}
+}
@@ -0,0 +1,128 @@
+// Package forwardemailtest provides a fake Forward Email API server for testing
+// code that uses the forwardemail package.
+package forwardemailtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+
+	"github.com/mattwebbio/go-forwardemail/forwardemail"
+)
+
+// Request is a request received by the Server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Response is a canned response served by the Server.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       string
+}
+
+// Server is an httptest.Server that records every request and answers with canned responses.
+// Requests to routes without a response get a 404.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	requests  []Request
+	responses map[string]Response
+}
+
+// NewServer starts a Server. Call Close when done with it.
+func NewServer() *Server {
+	s := &Server{
+		responses: map[string]Response{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Handle registers the response served for the method and path, e.g. ("GET", "/v1/domains").
+func (s *Server) Handle(method, path string, res Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses[method+" "+path] = res
+}
+
+// HandleJSON registers v, marshaled to JSON, as the response served for the method and path.
+func (s *Server) HandleJSON(method, path string, statusCode int, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("forwardemailtest: cannot marshal response: %v", err))
+	}
+
+	s.Handle(method, path, Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       string(body),
+	})
+}
+
+// Requests returns the requests received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// Reset forgets the recorded requests, keeping the registered responses.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+}
+
+// Client returns a forwardemail.Client pointed at the server.
+func (s *Server) Client(opts ...forwardemail.Option) *forwardemail.Client {
+	opts = append([]forwardemail.Option{forwardemail.WithBaseURL(s.URL)}, opts...)
+
+	return forwardemail.NewClient(forwardemail.ClientOptions{ApiKey: "test"}, opts...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	res, ok := s.responses[r.Method+" "+r.URL.Path]
+	s.mu.Unlock()
+
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"statusCode":404,"error":"Not Found","message":"Not Found"}`)
+		return
+	}
+
+	for k, v := range res.Header {
+		w.Header()[k] = v
+	}
+
+	statusCode := res.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	w.WriteHeader(statusCode)
+	fmt.Fprint(w, res.Body)
+}
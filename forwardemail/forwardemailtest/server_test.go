@@ -0,0 +1,72 @@
+package forwardemailtest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mattwebbio/go-forwardemail/forwardemail"
+)
+
+func TestServer(t *testing.T) {
+	svr := NewServer()
+	defer svr.Close()
+
+	svr.HandleJSON("GET", "/v1/domains/stark.com", http.StatusOK, map[string]any{
+		"name": "stark.com",
+		"plan": "enhanced_protection",
+	})
+
+	var domains forwardemail.DomainService = svr.Client()
+
+	got, err := domains.GetDomain("stark.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&forwardemail.Domain{Name: "stark.com", Plan: "enhanced_protection"}, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	err = domains.DeleteDomain("wayne.com")
+	if !forwardemail.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	var paths []string
+	for _, r := range svr.Requests() {
+		paths = append(paths, r.Method+" "+r.Path)
+	}
+	if diff := cmp.Diff([]string{"GET /v1/domains/stark.com", "DELETE /v1/domains/wayne.com"}, paths); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	svr.Reset()
+	if len(svr.Requests()) != 0 {
+		t.Fatalf("expected no requests after reset")
+	}
+}
+
+func TestServer_RecordsBodies(t *testing.T) {
+	svr := NewServer()
+	defer svr.Close()
+
+	svr.Handle("POST", "/v1/domains/stark.com/aliases", Response{
+		StatusCode: http.StatusOK,
+		Body:       `{"name": "tony"}`,
+	})
+
+	var aliases forwardemail.AliasService = svr.Client()
+
+	_, err := aliases.CreateAlias("stark.com", "tony", forwardemail.AliasParameters{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := svr.Requests()
+	if diff := cmp.Diff(`{"name":"tony"}`, string(requests[0].Body)); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+	if user, _, _ := (&http.Request{Header: requests[0].Header}).BasicAuth(); user != "test" {
+		t.Fatalf("expected the test api key, got %q", user)
+	}
+}
@@ -0,0 +1,128 @@
+package forwardemail
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// idnaProfile is the IDNA Lookup profile, mapping and validating names as resolvers do,
+// which also checks the lengths DNS allows for labels and names.
+var idnaProfile = idna.New(idna.MapForLookup(), idna.BidiRule(), idna.VerifyDNSLength(true))
+
+// DomainToASCII returns the ASCII form of a domain name, the one used by the API, following
+// the IDNA Lookup profile: "Bücher.de" becomes "xn--bcher-kva.de". Surrounding spaces and a
+// trailing dot are removed.
+func DomainToASCII(name string) (string, error) {
+	trimmed, err := trimDomain(name)
+	if err != nil {
+		return "", err
+	}
+
+	ascii, err := idnaProfile.ToASCII(trimmed)
+	if err != nil {
+		return "", fmt.Errorf("invalid domain name %q: %w", name, err)
+	}
+
+	return ascii, nil
+}
+
+// DomainToUnicode returns the form of a domain name meant for display, decoding the labels
+// encoded with punycode: "xn--bcher-kva.de" becomes "bücher.de". It is the reverse of
+// DomainToASCII.
+func DomainToUnicode(name string) (string, error) {
+	trimmed, err := trimDomain(name)
+	if err != nil {
+		return "", err
+	}
+
+	unicode, err := idnaProfile.ToUnicode(trimmed)
+	if err != nil {
+		return "", fmt.Errorf("invalid domain name %q: %w", name, err)
+	}
+
+	return unicode, nil
+}
+
+func trimDomain(name string) (string, error) {
+	trimmed := strings.TrimSuffix(strings.TrimSpace(name), ".")
+	if trimmed == "" {
+		return "", errors.New("empty domain name")
+	}
+
+	return trimmed, nil
+}
+
+// NormalizeAliasName returns the canonical form of an alias name, as the API stores it:
+// trimmed and lowercased. Regex aliases are only trimmed, their pattern being case-sensitive.
+func NormalizeAliasName(name string) string {
+	name = strings.TrimSpace(name)
+	if strings.HasPrefix(name, "/") {
+		return name
+	}
+
+	return strings.ToLower(name)
+}
+
+// NormalizeRecipient returns the canonical form of a recipient for comparisons: email
+// addresses are lowercased, and their domain name, like bare domain recipients, put in ASCII
+// form. Only the scheme and host of webhook URLs are lowercased, their path and query being
+// case-sensitive. Values that can't be parsed are only trimmed.
+func NormalizeRecipient(recipient string) string {
+	recipient = strings.TrimSpace(recipient)
+	if strings.Contains(recipient, "://") {
+		u, err := url.Parse(recipient)
+		if err != nil {
+			return recipient
+		}
+		u.Scheme = strings.ToLower(u.Scheme)
+		u.Host = strings.ToLower(u.Host)
+
+		return u.String()
+	}
+
+	recipient = strings.ToLower(recipient)
+	local, domain, hasAt := strings.Cut(recipient, "@")
+	if !hasAt {
+		domain, local = local, ""
+	}
+
+	ascii, err := DomainToASCII(domain)
+	if err != nil {
+		return recipient
+	}
+	if !hasAt {
+		return ascii
+	}
+
+	return local + "@" + ascii
+}
+
+// domainPathSegment converts internationalized domain names to their ASCII form before they
+// are put in a path. Names the conversion rejects are left for the API to refuse.
+func domainPathSegment(name string) string {
+	if isASCII(name) {
+		return name
+	}
+
+	ascii, err := DomainToASCII(name)
+	if err != nil {
+		return name
+	}
+
+	return ascii
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
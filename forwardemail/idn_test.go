@@ -0,0 +1,107 @@
+package forwardemail
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDomainToASCII(t *testing.T) {
+	tests := []struct {
+		name    string
+		domain  string
+		want    string
+		wantErr bool
+	}{
+		{name: "ascii", domain: "Stark.com", want: "stark.com"},
+		{name: "unicode", domain: "Bücher.de", want: "xn--bcher-kva.de"},
+		{name: "trailing dot and spaces", domain: " münchen.de. ", want: "xn--mnchen-3ya.de"},
+		{name: "ideographic full stop", domain: "例え。テスト", want: "xn--r8jz45g.xn--zckzah"},
+		{name: "already encoded", domain: "xn--bcher-kva.de", want: "xn--bcher-kva.de"},
+		{name: "empty", domain: " ", wantErr: true},
+		{name: "empty label", domain: "bücher..de", wantErr: true},
+		{name: "label too long", domain: "ü" + strings.Repeat("a", 70) + ".de", wantErr: true},
+		{name: "mapped to lowercase", domain: "BÜCHER.de", want: "xn--bcher-kva.de"},
+		{name: "disallowed character", domain: "stark_industries.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DomainToASCII(tt.domain)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestDomainToUnicode(t *testing.T) {
+	tests := []struct {
+		name    string
+		domain  string
+		want    string
+		wantErr bool
+	}{
+		{name: "ascii", domain: "stark.com", want: "stark.com"},
+		{name: "encoded", domain: "XN--bcher-kva.de.", want: "bücher.de"},
+		{name: "several labels", domain: "xn--r8jz45g.xn--zckzah", want: "例え.テスト"},
+		{name: "invalid", domain: "xn--b!.de", wantErr: true},
+		{name: "truncated", domain: "xn--bcher-kv9.de", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DomainToUnicode(tt.domain)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeRecipient(t *testing.T) {
+	tests := []struct {
+		recipient string
+		want      string
+	}{
+		{recipient: "Tony@Stark.com", want: "tony@stark.com"},
+		{recipient: "tony@bücher.de", want: "tony@xn--bcher-kva.de"},
+		{recipient: "Bücher.de", want: "xn--bcher-kva.de"},
+		{recipient: "HTTPS://Stark.com/Hook?Token=AbC", want: "https://stark.com/Hook?Token=AbC"},
+		{recipient: " pepper@stark.com ", want: "pepper@stark.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.recipient, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, NormalizeRecipient(tt.recipient)); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeAliasName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: " Tony ", want: "tony"},
+		{name: "*", want: "*"},
+		{name: "/^Support-.+$/", want: "/^Support-.+$/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, NormalizeAliasName(tt.name)); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
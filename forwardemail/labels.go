@@ -0,0 +1,126 @@
+package forwardemail
+
+import (
+	"context"
+	"strings"
+)
+
+// AddAliasLabels adds labels to an alias, keeping the labels it already has. Labels are
+// compared case-insensitively.
+func (c *Client) AddAliasLabels(domain string, alias string, labels []string, opts ...RequestOption) (*Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.AddAliasLabelsContext(ctx, domain, alias, labels)
+}
+
+func (c *Client) AddAliasLabelsContext(ctx context.Context, domain string, alias string, labels []string) (*Alias, error) {
+	return c.UpdateAliasLabelsContext(ctx, domain, alias, func(current []string) []string {
+		return mergeLabels(current, labels)
+	})
+}
+
+// RemoveAliasLabels removes labels from an alias. Labels are compared case-insensitively.
+func (c *Client) RemoveAliasLabels(domain string, alias string, labels []string, opts ...RequestOption) (*Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.RemoveAliasLabelsContext(ctx, domain, alias, labels)
+}
+
+func (c *Client) RemoveAliasLabelsContext(ctx context.Context, domain string, alias string, labels []string) (*Alias, error) {
+	return c.UpdateAliasLabelsContext(ctx, domain, alias, func(current []string) []string {
+		return withoutLabels(current, labels)
+	})
+}
+
+// SetAliasLabels replaces the labels of an alias.
+func (c *Client) SetAliasLabels(domain string, alias string, labels []string, opts ...RequestOption) (*Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.SetAliasLabelsContext(ctx, domain, alias, labels)
+}
+
+func (c *Client) SetAliasLabelsContext(ctx context.Context, domain string, alias string, labels []string) (*Alias, error) {
+	return c.UpdateAliasLabelsContext(ctx, domain, alias, func([]string) []string {
+		return mergeLabels(nil, labels)
+	})
+}
+
+// UpdateAliasLabels replaces the labels of an alias with the result of edit, which is given
+// its current labels. Only the labels are sent, and nothing is sent when they don't change.
+//
+// The API has no conditional updates: labels changed by someone else between the read of
+// the alias and the update are overwritten. Callers editing the labels of an alias
+// concurrently must coordinate on their own.
+func (c *Client) UpdateAliasLabels(domain string, alias string, edit func([]string) []string, opts ...RequestOption) (*Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.UpdateAliasLabelsContext(ctx, domain, alias, edit)
+}
+
+func (c *Client) UpdateAliasLabelsContext(ctx context.Context, domain string, alias string, edit func([]string) []string) (*Alias, error) {
+	current, err := c.GetAliasContext(ctx, domain, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := edit(append([]string(nil), current.Labels...))
+	if labels == nil {
+		labels = []string{}
+	}
+	if sameLabels(labels, current.Labels) {
+		return current, nil
+	}
+
+	return c.putAlias(ctx, domain, alias, aliasBody{AliasParameters: AliasParameters{Labels: &labels}})
+}
+
+// mergeLabels appends the labels of add missing from labels, dropping duplicates.
+func mergeLabels(labels []string, add []string) []string {
+	merged := make([]string, 0, len(labels)+len(add))
+	for _, label := range append(append([]string(nil), labels...), add...) {
+		if !containsLabel(merged, label) {
+			merged = append(merged, label)
+		}
+	}
+
+	return merged
+}
+
+func withoutLabels(labels []string, remove []string) []string {
+	kept := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if !containsLabel(remove, label) {
+			kept = append(kept, label)
+		}
+	}
+
+	return kept
+}
+
+func containsLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameLabels reports whether a and b hold the same labels in the same order.
+func sameLabels(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,113 @@
+package forwardemail
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_AliasLabels(t *testing.T) {
+	current := `{"name": "tony", "labels": ["ops", "Exec"], "updated_at": "2023-10-10T20:12:46.588Z"}`
+
+	tests := []struct {
+		name       string
+		update     func(c *Client) (*Alias, error)
+		wantLabels *[]string
+	}{
+		{
+			name: "add",
+			update: func(c *Client) (*Alias, error) {
+				return c.AddAliasLabels("stark.com", "tony", []string{"exec", "vip", "vip"})
+			},
+			wantLabels: &[]string{"ops", "Exec", "vip"},
+		},
+		{
+			name: "remove",
+			update: func(c *Client) (*Alias, error) {
+				return c.RemoveAliasLabels("stark.com", "tony", []string{"EXEC"})
+			},
+			wantLabels: &[]string{"ops"},
+		},
+		{
+			name: "set",
+			update: func(c *Client) (*Alias, error) {
+				return c.SetAliasLabels("stark.com", "tony", nil)
+			},
+			wantLabels: &[]string{},
+		},
+		{
+			name: "unchanged",
+			update: func(c *Client) (*Alias, error) {
+				return c.AddAliasLabels("stark.com", "tony", []string{"OPS"})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent *aliasBody
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == "PUT" {
+					body, _ := io.ReadAll(r.Body)
+					sent = &aliasBody{}
+					_ = json.Unmarshal(body, sent)
+				}
+
+				fmt.Fprint(w, current)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			if _, err := tt.update(c); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantLabels == nil {
+				if sent != nil {
+					t.Fatalf("expected no update, got %+v", sent)
+				}
+				return
+			}
+			if sent == nil {
+				t.Fatal("expected an update")
+			}
+			if diff := cmp.Diff(&aliasBody{AliasParameters: AliasParameters{Labels: tt.wantLabels}}, sent); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_UpdateAliasLabels_SingleRead(t *testing.T) {
+	var calls []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+
+		fmt.Fprint(w, `{"name": "tony", "labels": [], "updated_at": "2023-10-10T20:12:46.588Z"}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	if _, err := c.AddAliasLabels("stark.com", "tony", []string{"vip"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"GET /v1/domains/stark.com/aliases/tony",
+		"PUT /v1/domains/stark.com/aliases/tony",
+	}
+	if diff := cmp.Diff(want, calls); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
@@ -0,0 +1,112 @@
+package forwardemail
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// WithLogger logs every request sent by the client, along with retry attempts, to logger.
+// Entries carry the method, path, status and latency; credentials, passwords and request
+// or response bodies are never logged. Entries are written at slog.LevelDebug unless
+// WithLogLevel says otherwise.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithLogLevel sets the level of the entries written to the logger given to WithLogger.
+func WithLogLevel(level slog.Level) Option {
+	return func(c *Client) {
+		c.logLevel = level
+	}
+}
+
+type secretsKey struct{}
+
+// withSecrets marks the requests made with ctx as carrying plaintext secrets,
+// such as generated alias passwords, in their body or response.
+func withSecrets(ctx context.Context) context.Context {
+	return context.WithValue(ctx, secretsKey{}, true)
+}
+
+// HasSecrets reports whether the request carrying ctx sends or receives a plaintext secret,
+// such as a generated alias password. Hooks dumping requests or responses should skip them.
+func HasSecrets(ctx context.Context) bool {
+	secrets, _ := ctx.Value(secretsKey{}).(bool)
+
+	return secrets
+}
+
+// WithSecretsHiddenFromHooks stops the request and response hooks from being called for the
+// requests that carry plaintext secrets (see HasSecrets), so hooks written without that in
+// mind can never log them.
+func WithSecretsHiddenFromHooks() Option {
+	return func(c *Client) {
+		c.hideSecretsFromHooks = true
+	}
+}
+
+func (c *Client) logRequest(req *http.Request, res *http.Response, err error, latency time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.EscapedPath()),
+	}
+	if req.URL.RawQuery != "" {
+		attrs = append(attrs, slog.String("query", redactQuery(req.URL.Query()).Encode()))
+	}
+	attrs = append(attrs, slog.Duration("latency", latency))
+
+	if err != nil {
+		// url.Error repeats the whole URL, query included.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		attrs = append(attrs, slog.String("error", err.Error()))
+		c.logger.LogAttrs(req.Context(), c.logLevel, "forwardemail request failed", attrs...)
+		return
+	}
+
+	attrs = append(attrs, slog.Int("status", res.StatusCode))
+	c.logger.LogAttrs(req.Context(), c.logLevel, "forwardemail request", attrs...)
+}
+
+func (c *Client) logRetry(req *http.Request, attempt int, wait time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.LogAttrs(req.Context(), c.logLevel, "forwardemail retrying request",
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.EscapedPath()),
+		slog.Int("attempt", attempt+1),
+		slog.Duration("wait", wait),
+	)
+}
+
+// redactQuery hides the values of parameters that look like secrets.
+func redactQuery(query url.Values) url.Values {
+	for key, values := range query {
+		name := strings.ToLower(key)
+		if strings.Contains(name, "password") || strings.Contains(name, "key") ||
+			strings.Contains(name, "token") || strings.Contains(name, "secret") {
+			for i := range values {
+				values[i] = redacted
+			}
+		}
+	}
+
+	return query
+}
@@ -0,0 +1,90 @@
+package forwardemail
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_WithLogger(t *testing.T) {
+	attempts := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		fmt.Fprint(w, `{"name": "tony", "password": "s3cr3t-generated"}`)
+	}))
+	defer svr.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == "latency" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	c := NewClient(ClientOptions{
+		ApiKey:      "the-api-key",
+		ApiUrl:      svr.URL,
+		RetryPolicy: &RetryPolicy{MaxAttempts: 2},
+	}, WithLogger(logger), WithLogLevel(slog.LevelInfo))
+
+	req, err := c.NewRequest(http.MethodGet, "/v1/domains/stark.com/aliases?q=tony&api_key=the-api-key", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := c.DoRaw(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	want := []string{
+		`level=INFO msg="forwardemail request" method=GET path=/v1/domains/stark.com/aliases query="api_key=%5BREDACTED%5D&q=tony" status=503`,
+		`level=INFO msg="forwardemail retrying request" method=GET path=/v1/domains/stark.com/aliases attempt=2 wait=0s`,
+		`level=INFO msg="forwardemail request" method=GET path=/v1/domains/stark.com/aliases query="api_key=%5BREDACTED%5D&q=tony" status=200`,
+	}
+	got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+	if strings.Contains(buf.String(), "the-api-key") || strings.Contains(buf.String(), "s3cr3t") {
+		t.Fatalf("secrets were logged: %s", buf.String())
+	}
+}
+
+func TestClient_WithLogger_DefaultLevel(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	}, WithLogger(logger))
+
+	if _, err := c.GetAccount(); err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.Len() != 0 {
+		t.Fatalf("expected debug entries to be filtered out, got %s", buf.String())
+	}
+}
@@ -0,0 +1,197 @@
+package forwardemail
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LogEventKind classifies a log entry.
+type LogEventKind string
+
+const (
+	LogEventDelivered LogEventKind = "delivered"
+	LogEventBounce    LogEventKind = "bounce"
+	LogEventRejected  LogEventKind = "rejected"
+	LogEventSpam      LogEventKind = "spam"
+	LogEventUnknown   LogEventKind = "unknown"
+)
+
+// LogEntry is a Log along with its kind.
+type LogEntry struct {
+	Log
+	Kind LogEventKind
+}
+
+// logEventKind derives the kind of a log from its bounce category and response code: spam
+// is reported as such, 2xx codes are deliveries, and failures are bounces when the API
+// categorized them and rejections otherwise.
+func logEventKind(log Log) LogEventKind {
+	category := strings.ToLower(log.BounceCategory)
+
+	switch {
+	case category == "spam":
+		return LogEventSpam
+	case log.ResponseCode >= 200 && log.ResponseCode < 300:
+		return LogEventDelivered
+	case log.ResponseCode >= 400 && category != "" && category != "none":
+		return LogEventBounce
+	case log.ResponseCode >= 400:
+		return LogEventRejected
+	}
+
+	return LogEventUnknown
+}
+
+// LogIterator walks the logs of a domain from the oldest to the newest. Use it like a
+// bufio.Scanner:
+//
+//	it := client.IterateLogs(ctx, "stark.com", since, cursor)
+//	for it.Next() {
+//		ingest(it.Entry())
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//	cursor = it.Cursor()
+type LogIterator struct {
+	client *Client
+	ctx    context.Context
+	domain string
+	since  time.Time
+	lastID string
+	// newestFirst is set when starting from a position, the newer logs being listed first.
+	newestFirst bool
+
+	page    int
+	items   []Log
+	done    bool
+	current LogEntry
+	err     error
+}
+
+// IterateLogs returns an iterator over the logs of a domain created at or after since. A
+// non-empty cursor, as returned by LogIterator.Cursor, resumes right after the last entry
+// seen by a previous iteration and takes precedence over since.
+//
+// The API can't filter logs by date, so with a since or a cursor the logs are listed newest
+// first until the older ones are reached: the requests made are proportional to the number
+// of new logs rather than to all the logs of the domain, but those new logs are held in
+// memory before Next returns the first of them. Without either, the logs are listed oldest
+// first, one page at a time.
+func (c *Client) IterateLogs(ctx context.Context, domain string, since time.Time, cursor string) *LogIterator {
+	it := &LogIterator{client: c, ctx: ctx, domain: domain, since: since}
+	if cursor != "" {
+		it.since, it.lastID, it.err = decodeLogCursor(cursor)
+	}
+	it.newestFirst = !it.since.IsZero()
+
+	return it
+}
+
+// Next advances to the next entry, fetching pages as needed. It returns false at the end
+// of the logs or on error.
+func (it *LogIterator) Next() bool {
+	for it.err == nil {
+		for len(it.items) > 0 {
+			log := it.items[0]
+			it.items = it.items[1:]
+
+			if log.CreatedAt.Before(it.since) || (log.CreatedAt.Equal(it.since) && log.Id <= it.lastID) {
+				continue
+			}
+
+			it.current = LogEntry{Log: log, Kind: logEventKind(log)}
+			it.since, it.lastID = log.CreatedAt.Time, log.Id
+
+			return true
+		}
+
+		if it.done {
+			return false
+		}
+
+		if it.newestFirst {
+			it.items, it.err = it.newerLogs()
+			it.done = true
+			continue
+		}
+
+		it.page++
+		items, pagination, err := it.client.getLogsPage(it.ctx, it.domain, LogFilters{}, ListOptions{Page: it.page, Sort: "created_at"})
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.items = items
+		it.done = len(items) == 0 || !pagination.HasNextPage()
+	}
+
+	return false
+}
+
+// newerLogs lists the logs newest first up to the first page reaching the position of the
+// iterator, returning them oldest first. Next skips the older ones, as well as the logs
+// listed twice when new ones shift the pages during the listing.
+func (it *LogIterator) newerLogs() ([]Log, error) {
+	var logs []Log
+	for page := 1; ; page++ {
+		items, pagination, err := it.client.getLogsPage(it.ctx, it.domain, LogFilters{}, ListOptions{Page: page, Sort: "-created_at"})
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, items...)
+		if len(items) == 0 || !pagination.HasNextPage() || items[len(items)-1].CreatedAt.Before(it.since) {
+			break
+		}
+	}
+
+	sort.SliceStable(logs, func(i, j int) bool {
+		if !logs[i].CreatedAt.Equal(logs[j].CreatedAt.Time) {
+			return logs[i].CreatedAt.Before(logs[j].CreatedAt.Time)
+		}
+		return logs[i].Id < logs[j].Id
+	})
+
+	return logs, nil
+}
+
+// Entry returns the entry Next advanced to.
+func (it *LogIterator) Entry() LogEntry {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *LogIterator) Err() error {
+	return it.err
+}
+
+// Cursor returns an opaque cursor pointing after the last entry returned by Next,
+// to be passed to IterateLogs to resume later.
+func (it *LogIterator) Cursor() string {
+	if it.since.IsZero() {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(it.since.Format(time.RFC3339Nano) + " " + it.lastID))
+}
+
+func decodeLogCursor(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("invalid log cursor: %w", err)
+	}
+
+	timestamp, id, _ := strings.Cut(string(raw), " ")
+	since, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("invalid log cursor: %w", err)
+	}
+
+	return since, id, nil
+}
@@ -0,0 +1,157 @@
+package forwardemail
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_IterateLogs(t *testing.T) {
+	logs := []string{
+		`{"id": "01", "response_code": 250, "created_at": "2024-01-01T10:00:00Z"}`,
+		`{"id": "02", "response_code": 550, "bounce_category": "block", "created_at": "2024-01-01T11:00:00Z"}`,
+		`{"id": "03", "response_code": 554, "bounce_category": "spam", "created_at": "2024-01-01T11:00:00Z"}`,
+		`{"id": "04", "response_code": 550, "created_at": "2024-01-01T12:00:00Z"}`,
+		`{"id": "05", "response_code": 250, "created_at": "2024-01-01T13:00:00Z"}`,
+		`{"id": "06", "response_code": 250, "created_at": "2024-01-01T14:00:00Z"}`,
+	}
+
+	var queries []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+
+		sorted := logs
+		if r.URL.Query().Get("sort") == "-created_at" {
+			sorted = make([]string, len(logs))
+			for i, log := range logs {
+				sorted[len(logs)-1-i] = log
+			}
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		w.Header().Set("X-Page-Count", "3")
+		w.Header().Set("X-Page-Current", strconv.Itoa(page))
+		fmt.Fprint(w, "["+strings.Join(sorted[(page-1)*2:page*2], ",")+"]")
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	type entry struct {
+		Id   string
+		Kind LogEventKind
+	}
+	collect := func(it *LogIterator) []entry {
+		var got []entry
+		for it.Next() {
+			got = append(got, entry{Id: it.Entry().Id, Kind: it.Entry().Kind})
+		}
+		if err := it.Err(); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	ctx := context.Background()
+
+	var ids []string
+	for _, entry := range collect(c.IterateLogs(ctx, "stark.com", time.Time{}, "")) {
+		ids = append(ids, entry.Id)
+	}
+	if diff := cmp.Diff([]string{"01", "02", "03", "04", "05", "06"}, ids); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	wantQueries := []string{
+		"domain=stark.com&page=1&sort=created_at",
+		"domain=stark.com&page=2&sort=created_at",
+		"domain=stark.com&page=3&sort=created_at",
+	}
+	if diff := cmp.Diff(wantQueries, queries); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	// A since only lists the pages of the newer logs, newest first.
+	queries = nil
+	it := c.IterateLogs(ctx, "stark.com", time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), "")
+	want := []entry{
+		{Id: "04", Kind: LogEventRejected},
+		{Id: "05", Kind: LogEventDelivered},
+		{Id: "06", Kind: LogEventDelivered},
+	}
+	if diff := cmp.Diff(want, collect(it)); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	wantQueries = []string{
+		"domain=stark.com&page=1&sort=-created_at",
+		"domain=stark.com&page=2&sort=-created_at",
+	}
+	if diff := cmp.Diff(wantQueries, queries); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	// Resuming from the middle of entries sharing a timestamp.
+	first := c.IterateLogs(ctx, "stark.com", time.Time{}, "")
+	if !first.Next() || !first.Next() {
+		t.Fatal("expected two entries")
+	}
+
+	resumed := c.IterateLogs(ctx, "stark.com", time.Time{}, first.Cursor())
+	want = []entry{
+		{Id: "03", Kind: LogEventSpam},
+		{Id: "04", Kind: LogEventRejected},
+		{Id: "05", Kind: LogEventDelivered},
+		{Id: "06", Kind: LogEventDelivered},
+	}
+	if diff := cmp.Diff(want, collect(resumed)); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	// A cursor taken at the end yields nothing new.
+	if got := collect(c.IterateLogs(ctx, "stark.com", time.Time{}, resumed.Cursor())); len(got) != 0 {
+		t.Fatalf("expected no entries, got %v", got)
+	}
+}
+
+func TestClient_IterateLogs_InvalidCursor(t *testing.T) {
+	c := NewClient(ClientOptions{})
+
+	it := c.IterateLogs(context.Background(), "stark.com", time.Time{}, "not a cursor")
+	if it.Next() {
+		t.Fatal("expected no entries")
+	}
+	if it.Err() == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func Test_logEventKind(t *testing.T) {
+	tests := []struct {
+		log  Log
+		want LogEventKind
+	}{
+		{log: Log{ResponseCode: 250}, want: LogEventDelivered},
+		{log: Log{ResponseCode: 421, BounceCategory: "network"}, want: LogEventBounce},
+		{log: Log{ResponseCode: 550, BounceCategory: "none"}, want: LogEventRejected},
+		{log: Log{ResponseCode: 550, BounceCategory: "Spam"}, want: LogEventSpam},
+		{log: Log{}, want: LogEventUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.want), func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, logEventKind(tt.log)); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
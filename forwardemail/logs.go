@@ -0,0 +1,158 @@
+package forwardemail
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type Log struct {
+	Id             string         `json:"id"`
+	Object         string         `json:"object"`
+	Message        string         `json:"message"`
+	BounceCategory string         `json:"bounce_category"`
+	ResponseCode   int            `json:"response_code"`
+	Meta           map[string]any `json:"meta"`
+	CreatedAt      Timestamp      `json:"created_at"`
+}
+
+type LogFilters struct {
+	Search         string
+	BounceCategory string
+	ResponseCode   int
+}
+
+func (f LogFilters) values(domain string) url.Values {
+	params := url.Values{}
+	if domain != "" {
+		params.Add("domain", domain)
+	}
+	if f.Search != "" {
+		params.Add("q", f.Search)
+	}
+	if f.BounceCategory != "" {
+		params.Add("bounce_category", f.BounceCategory)
+	}
+	if f.ResponseCode != 0 {
+		params.Add("response_code", strconv.Itoa(f.ResponseCode))
+	}
+
+	return params
+}
+
+// GetLogs returns the delivery logs of a domain matching the filters.
+func (c *Client) GetLogs(domain string, filters LogFilters, opts ...RequestOption) ([]Log, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetLogsContext(ctx, domain, filters)
+}
+
+func (c *Client) GetLogsContext(ctx context.Context, domain string, filters LogFilters) ([]Log, error) {
+	items, _, err := c.getLogsPage(ctx, domain, filters, ListOptions{})
+
+	return items, err
+}
+
+func (c *Client) getLogsPage(ctx context.Context, domain string, filters LogFilters, options ListOptions) ([]Log, *Pagination, error) {
+	params := filters.values(domain)
+	for k, v := range options.values() {
+		params[k] = v
+	}
+
+	path := "/v1/logs"
+	if query := params.Encode(); query != "" {
+		path += "?" + query
+	}
+
+	req, err := c.newRequest(ctx, "GET", path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, header, err := c.doRequestWithHeader(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var items []Log
+
+	err = c.unmarshal(req, res, &items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return items, parsePagination(header), nil
+}
+
+// DownloadLogs streams the logs export as CSV. The export is served gzipped and is
+// decompressed on the fly. An empty domain downloads the logs of every domain.
+// The caller is responsible for closing the returned reader.
+func (c *Client) DownloadLogs(domain string, filters LogFilters, opts ...RequestOption) (io.ReadCloser, error) {
+	ctx, cancel := requestContext(opts)
+
+	body, err := c.DownloadLogsContext(ctx, domain, filters)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return cancelOnClose{ReadCloser: body, cancel: cancel}, nil
+}
+
+func (c *Client) DownloadLogsContext(ctx context.Context, domain string, filters LogFilters) (io.ReadCloser, error) {
+	path := "/v1/logs/download"
+	if query := filters.values(domain).Encode(); query != "" {
+		path += "?" + query
+	}
+
+	req, err := c.newRequest(ctx, "GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRaw(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, c.newAPIError(res, body)
+	}
+
+	switch res.Header.Get("Content-Type") {
+	case "application/gzip", "application/x-gzip":
+		gz, err := gzip.NewReader(res.Body)
+		if err != nil {
+			res.Body.Close()
+			return nil, err
+		}
+
+		return &gzipReadCloser{Reader: gz, body: res.Body}, nil
+	}
+
+	return res.Body, nil
+}
+
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.Closer
+}
+
+func (g *gzipReadCloser) Close() error {
+	if err := g.Reader.Close(); err != nil {
+		g.body.Close()
+		return err
+	}
+
+	return g.body.Close()
+}
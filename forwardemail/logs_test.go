@@ -0,0 +1,154 @@
+package forwardemail
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_GetLogs(t *testing.T) {
+	tests := []struct {
+		name      string
+		domain    string
+		filters   LogFilters
+		res       string
+		wantQuery string
+		want      []Log
+	}{
+		{
+			name: "no data",
+		},
+		{
+			name:   "ok",
+			domain: "stark.com",
+			filters: LogFilters{
+				BounceCategory: "spam",
+				ResponseCode:   550,
+			},
+			res: `[
+				{
+				  "id": "6525b03e0bde8f333ace5824",
+				  "object": "log",
+				  "message": "Message rejected as spam",
+				  "bounce_category": "spam",
+				  "response_code": 550,
+				  "meta": {"level": "error"},
+				  "created_at": "2023-10-10T20:12:46.588Z"
+				}
+			]`,
+			wantQuery: "bounce_category=spam&domain=stark.com&response_code=550",
+			want: []Log{
+				{
+					Id:             "6525b03e0bde8f333ace5824",
+					Object:         "log",
+					Message:        "Message rejected as spam",
+					BounceCategory: "spam",
+					ResponseCode:   550,
+					Meta:           map[string]any{"level": "error"},
+					CreatedAt:      Timestamp{parseTime("2023-10-10T20:12:46.588Z")},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.RawQuery
+
+				fmt.Fprint(w, tt.res)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, _ := c.GetLogs(tt.domain, tt.filters)
+			if diff := cmp.Diff(tt.wantQuery, query); diff != "" {
+				t.Fatalf("queries are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_DownloadLogs(t *testing.T) {
+	const csv = "id,message\n6525b03e0bde8f333ace5824,delivered\n"
+
+	tests := []struct {
+		name        string
+		contentType string
+		gzipped     bool
+	}{
+		{
+			name:        "plain csv",
+			contentType: "text/csv",
+		},
+		{
+			name:        "gzipped csv",
+			contentType: "application/gzip",
+			gzipped:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				if !tt.gzipped {
+					fmt.Fprint(w, csv)
+					return
+				}
+
+				gz := gzip.NewWriter(w)
+				fmt.Fprint(gz, csv)
+				gz.Close()
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			rc, err := c.DownloadLogs("stark.com", LogFilters{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rc.Close()
+
+			got, err := io.ReadAll(rc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(csv, string(got)); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_DownloadLogs_Error(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, "oh no")
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	_, err := c.DownloadLogs("", LogFilters{})
+	if !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
@@ -0,0 +1,296 @@
+package forwardemail
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// ErrNoAliasCredentials is returned by the mailbox methods when the client was created without
+// WithAliasCredentials or WithAliasAuthenticator.
+var ErrNoAliasCredentials = errors.New("forwardemail: mailbox endpoints need alias credentials, see WithAliasCredentials")
+
+// WithAliasCredentials sets the credentials of an alias with IMAP storage, used by the mailbox
+// methods (messages, folders, contacts and calendars) instead of the API key. The username is the alias
+// address and the password one generated with GenerateAliasPassword. Every other method keeps
+// using the API key, so a single client can manage domains and read a mailbox.
+func WithAliasCredentials(username, password string) Option {
+	return func(c *Client) {
+		c.aliasAuth = BasicAuth{Username: username, Password: password}
+	}
+}
+
+// newAliasRequest builds a request authenticated with the alias credentials.
+func (c *Client) newAliasRequest(ctx context.Context, method, path string) (*http.Request, error) {
+	if c.aliasAuth == nil {
+		return nil, ErrNoAliasCredentials
+	}
+
+	req, err := c.newRequest(ctx, method, path)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.aliasAuth.Authenticate(req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// doMailboxJSON sends a request authenticated with the alias credentials, with body encoded
+// as JSON when not nil, and decodes the response into out when not nil.
+func (c *Client) doMailboxJSON(ctx context.Context, method, path string, body any, out any) error {
+	req, err := c.newAliasRequest(ctx, method, path)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		if err := setJSONBody(req, body); err != nil {
+			return err
+		}
+	}
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return c.unmarshal(req, res, out)
+}
+
+// Folder is an IMAP folder of an alias mailbox.
+type Folder struct {
+	Id          string    `json:"id"`
+	Object      string    `json:"object"`
+	Path        string    `json:"path"`
+	Name        string    `json:"name"`
+	SpecialUse  string    `json:"special_use"`
+	Subscribed  bool      `json:"subscribed"`
+	UidValidity int64     `json:"uid_validity"`
+	UidNext     int64     `json:"uid_next"`
+	CreatedAt   Timestamp `json:"created_at"`
+	UpdatedAt   Timestamp `json:"updated_at"`
+}
+
+// Message is a message stored in an alias mailbox. Use DownloadMessage for its raw content.
+type Message struct {
+	Id        string    `json:"id"`
+	Object    string    `json:"object"`
+	FolderId  string    `json:"folder_id"`
+	Folder    string    `json:"folder_path"`
+	Uid       int64     `json:"uid"`
+	ThreadId  string    `json:"thread_id"`
+	Subject   string    `json:"subject"`
+	From      string    `json:"from"`
+	To        []string  `json:"to"`
+	Cc        []string  `json:"cc"`
+	Flags     []string  `json:"flags"`
+	Labels    []string  `json:"labels"`
+	IsUnread  bool      `json:"is_unread"`
+	IsFlagged bool      `json:"is_flagged"`
+	Size      int64     `json:"size"`
+	Date      Timestamp `json:"header_date"`
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+}
+
+// MessageFilters narrows down the messages returned by GetMessages. Zero values are left out.
+type MessageFilters struct {
+	// Folder is the path of a folder, e.g. "INBOX".
+	Folder    string
+	IsUnread  *bool
+	IsFlagged *bool
+}
+
+func (f MessageFilters) query(options ListOptions) string {
+	params := options.values()
+	if f.Folder != "" {
+		params.Add("folder", f.Folder)
+	}
+	if f.IsUnread != nil {
+		params.Add("is_unread", strconv.FormatBool(*f.IsUnread))
+	}
+	if f.IsFlagged != nil {
+		params.Add("is_flagged", strconv.FormatBool(*f.IsFlagged))
+	}
+
+	if query := params.Encode(); query != "" {
+		return "?" + query
+	}
+
+	return ""
+}
+
+// GetFolders returns the folders of the mailbox of the alias credentials.
+func (c *Client) GetFolders(opts ...RequestOption) ([]Folder, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetFoldersContext(ctx)
+}
+
+func (c *Client) GetFoldersContext(ctx context.Context) ([]Folder, error) {
+	req, err := c.newAliasRequest(ctx, "GET", "/v1/folders")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []Folder
+
+	err = c.unmarshal(req, res, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (c *Client) GetFolder(id string, opts ...RequestOption) (*Folder, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetFolderContext(ctx, id)
+}
+
+func (c *Client) GetFolderContext(ctx context.Context, id string) (*Folder, error) {
+	req, err := c.newAliasRequest(ctx, "GET", pathf("/v1/folders/%s", id))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var item Folder
+
+	err = c.unmarshal(req, res, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// GetMessages returns every message of the mailbox matching the filters, fetching all pages.
+func (c *Client) GetMessages(filters MessageFilters, opts ...RequestOption) ([]Message, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetMessagesContext(ctx, filters)
+}
+
+func (c *Client) GetMessagesContext(ctx context.Context, filters MessageFilters) ([]Message, error) {
+	return collectPages(1, func(page int) ([]Message, *Pagination, error) {
+		return c.GetMessagesPageContext(ctx, filters, ListOptions{Page: page})
+	})
+}
+
+// GetMessagesPage returns a single page of messages along with the pagination details.
+func (c *Client) GetMessagesPage(filters MessageFilters, options ListOptions, opts ...RequestOption) ([]Message, *Pagination, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetMessagesPageContext(ctx, filters, options)
+}
+
+func (c *Client) GetMessagesPageContext(ctx context.Context, filters MessageFilters, options ListOptions) ([]Message, *Pagination, error) {
+	req, err := c.newAliasRequest(ctx, "GET", "/v1/messages"+filters.query(options))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, header, err := c.doRequestWithHeader(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var items []Message
+
+	err = c.unmarshal(req, res, &items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return items, parsePagination(header), nil
+}
+
+func (c *Client) GetMessage(id string, opts ...RequestOption) (*Message, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetMessageContext(ctx, id)
+}
+
+func (c *Client) GetMessageContext(ctx context.Context, id string) (*Message, error) {
+	req, err := c.newAliasRequest(ctx, "GET", pathf("/v1/messages/%s", id))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var item Message
+
+	err = c.unmarshal(req, res, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// DownloadMessage streams the raw RFC 822 content of a message.
+// The caller is responsible for closing the returned reader.
+func (c *Client) DownloadMessage(id string, opts ...RequestOption) (io.ReadCloser, error) {
+	ctx, cancel := requestContext(opts)
+
+	body, err := c.DownloadMessageContext(ctx, id)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return cancelOnClose{ReadCloser: body, cancel: cancel}, nil
+}
+
+func (c *Client) DownloadMessageContext(ctx context.Context, id string) (io.ReadCloser, error) {
+	req, err := c.newAliasRequest(ctx, "GET", pathf("/v1/messages/%s", id))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "message/rfc822")
+
+	res, err := c.DoRaw(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, c.newAPIError(res, body)
+	}
+
+	return res.Body, nil
+}
@@ -0,0 +1,145 @@
+package forwardemail
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newMailboxServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		if username != "tony@stark.com" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		handler(w, r)
+	}))
+	t.Cleanup(svr.Close)
+
+	return svr
+}
+
+func TestClient_GetFolders(t *testing.T) {
+	svr := newMailboxServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/folders" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		fmt.Fprint(w, `[{"id": "6489e2d4dbe0e1050e6ef5a0", "object": "folder", "path": "INBOX", "name": "INBOX", "subscribed": true, "uid_next": 12}]`)
+	})
+
+	c := NewClient(ClientOptions{ApiKey: "api_key", ApiUrl: svr.URL}, WithAliasCredentials("tony@stark.com", "secret"))
+
+	got, err := c.GetFolders()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Folder{{Id: "6489e2d4dbe0e1050e6ef5a0", Object: "folder", Path: "INBOX", Name: "INBOX", Subscribed: true, UidNext: 12}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+}
+
+func TestClient_GetMessages(t *testing.T) {
+	var queries []string
+	svr := newMailboxServer(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+
+		w.Header().Set("X-Page-Count", "2")
+		w.Header().Set("X-Page-Current", r.URL.Query().Get("page"))
+		fmt.Fprintf(w, `[{"id": "m%s", "folder_path": "INBOX", "subject": "Hello", "is_unread": true}]`, r.URL.Query().Get("page"))
+	})
+
+	c := NewClient(ClientOptions{ApiUrl: svr.URL}, WithAliasCredentials("tony@stark.com", "secret"))
+
+	got, err := c.GetMessages(MessageFilters{Folder: "INBOX", IsUnread: pointBool(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Message{
+		{Id: "m1", Folder: "INBOX", Subject: "Hello", IsUnread: true},
+		{Id: "m2", Folder: "INBOX", Subject: "Hello", IsUnread: true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+
+	wantQueries := []string{"folder=INBOX&is_unread=true&page=1", "folder=INBOX&is_unread=true&page=2"}
+	if diff := cmp.Diff(wantQueries, queries); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+}
+
+func TestClient_DownloadMessage(t *testing.T) {
+	svr := newMailboxServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/messages/m1" || r.Header.Get("Accept") != "message/rfc822" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Message does not exist."}`)
+			return
+		}
+
+		w.Header().Set("Content-Type", "message/rfc822")
+		fmt.Fprint(w, "Subject: Hello\r\n\r\nHi Tony\r\n")
+	})
+
+	c := NewClient(ClientOptions{ApiUrl: svr.URL}, WithAliasCredentials("tony@stark.com", "secret"))
+
+	body, err := c.DownloadMessage("m1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("Subject: Hello\r\n\r\nHi Tony\r\n", string(raw)); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+
+	if _, err := c.DownloadMessage("m2"); !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestClient_Mailbox_WithoutAliasCredentials(t *testing.T) {
+	c := NewClient(ClientOptions{ApiKey: "api_key", ApiUrl: "http://127.0.0.1:0"})
+
+	if _, err := c.GetMessage("m1"); !errors.Is(err, ErrNoAliasCredentials) {
+		t.Errorf("expected ErrNoAliasCredentials, got %v", err)
+	}
+}
+
+func TestClient_AliasCredentials_OnlyForMailbox(t *testing.T) {
+	var auth []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, _, _ := r.BasicAuth()
+		auth = append(auth, r.URL.Path+" "+username)
+
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{ApiKey: "api_key", ApiUrl: svr.URL}, WithAliasCredentials("tony@stark.com", "secret"))
+
+	_, _ = c.GetAccount()
+	_, _ = c.GetFolder("INBOX")
+
+	want := []string{"/v1/account api_key", "/v1/folders/INBOX tony@stark.com"}
+	if diff := cmp.Diff(want, auth); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+}
@@ -0,0 +1,157 @@
+package forwardemail
+
+import (
+	"context"
+	"net/url"
+)
+
+type Member struct {
+	User  AccountOrID `json:"user"`
+	Group string      `json:"group"`
+}
+
+type Invite struct {
+	Email string `json:"email"`
+	Group string `json:"group"`
+}
+
+// ListDomainMembers returns the members of a domain, as listed on the domain itself.
+func (c *Client) ListDomainMembers(domain string, opts ...RequestOption) ([]Member, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.ListDomainMembersContext(ctx, domain)
+}
+
+func (c *Client) ListDomainMembersContext(ctx context.Context, domain string) ([]Member, error) {
+	item, err := c.GetDomainContext(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return item.Members, nil
+}
+
+// InviteDomainMember invites an email to a domain as part of the given group ("admin" or "user").
+func (c *Client) InviteDomainMember(domain string, email string, group string, opts ...RequestOption) (*Domain, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.InviteDomainMemberContext(ctx, domain, email, group)
+}
+
+func (c *Client) InviteDomainMemberContext(ctx context.Context, domain string, email string, group string) (*Domain, error) {
+	req, err := c.newRequest(ctx, "POST", pathf("/v1/domains/%s/invites", domain))
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Add("email", email)
+	params.Add("group", group)
+
+	setFormBody(req, params)
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var item Domain
+
+	err = c.unmarshal(req, res, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) RemoveDomainMember(domain string, memberID string, opts ...RequestOption) error {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.RemoveDomainMemberContext(ctx, domain, memberID)
+}
+
+func (c *Client) RemoveDomainMemberContext(ctx context.Context, domain string, memberID string) error {
+	req, err := c.newRequest(ctx, "DELETE", pathf("/v1/domains/%s/members/%s", domain, memberID))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RemoveDomainInvite withdraws a pending invite sent to the given email.
+func (c *Client) RemoveDomainInvite(domain string, email string, opts ...RequestOption) (*Domain, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.RemoveDomainInviteContext(ctx, domain, email)
+}
+
+func (c *Client) RemoveDomainInviteContext(ctx context.Context, domain string, email string) (*Domain, error) {
+	req, err := c.newRequest(ctx, "DELETE", pathf("/v1/domains/%s/invites", domain))
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Add("email", email)
+
+	setFormBody(req, params)
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var item Domain
+
+	err = c.unmarshal(req, res, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// UpdateDomainMember moves a member to another group ("admin" or "user").
+func (c *Client) UpdateDomainMember(domain string, memberID string, group string, opts ...RequestOption) (*Domain, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.UpdateDomainMemberContext(ctx, domain, memberID, group)
+}
+
+func (c *Client) UpdateDomainMemberContext(ctx context.Context, domain string, memberID string, group string) (*Domain, error) {
+	req, err := c.newRequest(ctx, "PUT", pathf("/v1/domains/%s/members/%s", domain, memberID))
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Add("group", group)
+
+	setFormBody(req, params)
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var item Domain
+
+	err = c.unmarshal(req, res, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
@@ -0,0 +1,320 @@
+package forwardemail
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_ListDomainMembers(t *testing.T) {
+	tests := []struct {
+		name   string
+		domain string
+		res    string
+		want   []Member
+	}{
+		{
+			name: "no data",
+		},
+		{
+			name:   "ok",
+			domain: "stark.com",
+			res: `{
+				"name": "stark.com",
+				"members": [
+				  {
+					"user": {
+					  "email": "tony@stark.com",
+					  "id": "59ad551ae6fb4a4c53427ca38079f029"
+					},
+					"group": "admin"
+				  },
+				  {
+					"user": "6525b03e0bde8f333ace5824",
+					"group": "user"
+				  }
+				]
+			}`,
+			want: []Member{
+				{
+					User: AccountOrID{
+						Account: &Account{
+							Email: "tony@stark.com",
+							Id:    "59ad551ae6fb4a4c53427ca38079f029",
+						},
+						ID: "59ad551ae6fb4a4c53427ca38079f029",
+					},
+					Group: "admin",
+				},
+				{
+					User: AccountOrID{
+						ID: "6525b03e0bde8f333ace5824",
+					},
+					Group: "user",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, tt.res)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, _ := c.ListDomainMembers(tt.domain)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_InviteDomainMember(t *testing.T) {
+	type request struct {
+		domain string
+		email  string
+		group  string
+	}
+
+	tests := []struct {
+		name string
+		req  request
+		res  string
+		want *Domain
+	}{
+		{
+			name: "no data",
+		},
+		{
+			name: "ok",
+			req: request{
+				domain: "stark.com",
+				email:  "pepper@potts.com",
+				group:  "user",
+			},
+			res: `{
+				"name": "stark.com",
+				"invites": [
+				  {
+					"email": "pepper@potts.com",
+					"group": "user"
+				  }
+				]
+			}`,
+			want: &Domain{
+				Name: "stark.com",
+				Invites: []Invite{
+					{Email: "pepper@potts.com", Group: "user"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				logRequestBody(r, t)
+
+				fmt.Fprintf(w, tt.res)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, _ := c.InviteDomainMember(tt.req.domain, tt.req.email, tt.req.group)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_RemoveDomainMember(t *testing.T) {
+	type response struct {
+		code int
+		body string
+	}
+
+	tests := []struct {
+		name   string
+		domain string
+		member string
+		res    response
+		want   error
+	}{
+		{
+			name:   "ok",
+			domain: "stark.com",
+			member: "59ad551ae6fb4a4c53427ca38079f029",
+			res: response{
+				code: http.StatusOK,
+			},
+		},
+		{
+			name: "not ok",
+			res: response{
+				code: http.StatusInternalServerError,
+				body: "oh no",
+			},
+			want: &APIError{StatusCode: http.StatusInternalServerError, Message: "oh no", Body: []byte("oh no")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.res.code)
+				fmt.Fprintf(w, tt.res.body)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got := c.RemoveDomainMember(tt.domain, tt.member)
+			if diff := cmp.Diff(tt.want, got, cmp.Comparer(equateErrorMessage)); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_RemoveDomainInvite(t *testing.T) {
+	type request struct {
+		domain string
+		email  string
+	}
+
+	tests := []struct {
+		name     string
+		req      request
+		res      string
+		wantBody string
+		want     *Domain
+	}{
+		{
+			name:     "no data",
+			wantBody: "email=",
+		},
+		{
+			name: "ok",
+			req: request{
+				domain: "stark.com",
+				email:  "pepper@potts.com",
+			},
+			res:      `{"name": "stark.com", "invites": []}`,
+			wantBody: "email=pepper%40potts.com",
+			want: &Domain{
+				Name:    "stark.com",
+				Invites: []Invite{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				body = string(b)
+
+				fmt.Fprint(w, tt.res)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, _ := c.RemoveDomainInvite(tt.req.domain, tt.req.email)
+			if diff := cmp.Diff(tt.wantBody, body); diff != "" {
+				t.Fatalf("request bodies are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_UpdateDomainMember(t *testing.T) {
+	type request struct {
+		domain string
+		member string
+		group  string
+	}
+
+	tests := []struct {
+		name     string
+		req      request
+		res      string
+		wantPath string
+		want     *Domain
+	}{
+		{
+			name: "ok",
+			req: request{
+				domain: "stark.com",
+				member: "59ad551ae6fb4a4c53427ca38079f029",
+				group:  "admin",
+			},
+			res: `{
+				"name": "stark.com",
+				"members": [
+				  {
+					"user": "59ad551ae6fb4a4c53427ca38079f029",
+					"group": "admin"
+				  }
+				]
+			}`,
+			wantPath: "/v1/domains/stark.com/members/59ad551ae6fb4a4c53427ca38079f029",
+			want: &Domain{
+				Name: "stark.com",
+				Members: []Member{
+					{
+						User:  AccountOrID{ID: "59ad551ae6fb4a4c53427ca38079f029"},
+						Group: "admin",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				logRequestBody(r, t)
+
+				fmt.Fprint(w, tt.res)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, _ := c.UpdateDomainMember(tt.req.domain, tt.req.member, tt.req.group)
+			if diff := cmp.Diff(tt.wantPath, path); diff != "" {
+				t.Fatalf("paths are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package forwardemail
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Option customizes a Client created by NewClient.
+type Option func(*Client)
+
+// WithBaseURL points the client to another API URL, e.g. a self-hosted Forward Email instance.
+func WithBaseURL(url string) Option {
+	return func(c *Client) {
+		c.ApiUrl = url
+	}
+}
+
+// WithHTTPClient uses the given *http.Client instead of the default one, whose transport is
+// tuned for the API.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.HttpClient = httpClient
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithTimeout sets a timeout on every request. The HTTP client in use is copied,
+// so a shared client such as the default one is never modified.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithHeader adds a header sent with every request.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		if c.Headers == nil {
+			c.Headers = http.Header{}
+		}
+		c.Headers.Add(key, value)
+	}
+}
+
+// WithRequestHook registers a function called with every outgoing request, including retries,
+// right before it is sent. Hooks may modify the request, e.g. to add headers.
+func WithRequestHook(hook func(*http.Request)) Option {
+	return func(c *Client) {
+		c.requestHooks = append(c.requestHooks, hook)
+	}
+}
+
+// WithResponseHook registers a function called with every response received, including
+// those that end up being retried. Hooks must not consume the response body.
+func WithResponseHook(hook func(*http.Response)) Option {
+	return func(c *Client) {
+		c.responseHooks = append(c.responseHooks, hook)
+	}
+}
+
+// RequestOption customizes a single call of a method that doesn't take a context. Methods
+// taking a context don't need them: set a deadline on the context instead.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	timeout  time.Duration
+	deadline time.Time
+	response *Response
+}
+
+// WithRequestTimeout limits the duration of a single call, including its retries and, for
+// calls made of several requests such as UpdateAlias, every one of them. Unlike the client-wide
+// WithTimeout, it can be longer than the timeout of the HTTP client, which still applies to
+// each request.
+func WithRequestTimeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithRequestDeadline makes a single call fail once the deadline is reached.
+func WithRequestDeadline(deadline time.Time) RequestOption {
+	return func(o *requestOptions) {
+		o.deadline = deadline
+	}
+}
+
+// requestContext returns the context of a call made with opts. The earliest of the timeout
+// and the deadline wins when both are set.
+func requestContext(opts []RequestOption) (context.Context, context.CancelFunc) {
+	var o requestOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	deadline := o.deadline
+	if o.timeout > 0 {
+		if d := time.Now().Add(o.timeout); deadline.IsZero() || d.Before(deadline) {
+			deadline = d
+		}
+	}
+
+	ctx := context.Background()
+	if o.response != nil {
+		ctx = ContextWithResponse(ctx, o.response)
+	}
+
+	if deadline.IsZero() {
+		return ctx, func() {}
+	}
+
+	return context.WithDeadline(ctx, deadline)
+}
+
+// cancelOnClose releases the context of a streamed response once the caller is done with it.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (r cancelOnClose) Close() error {
+	defer r.cancel()
+
+	return r.ReadCloser.Close()
+}
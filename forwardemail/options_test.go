@@ -0,0 +1,236 @@
+package forwardemail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewClient_Options(t *testing.T) {
+	customHttpClient := &http.Client{Timeout: time.Minute}
+
+	tests := []struct {
+		name    string
+		options ClientOptions
+		opts    []Option
+		want    *Client
+	}{
+		{
+			name: "base url",
+			opts: []Option{WithBaseURL("https://mail.stark.com")},
+			want: &Client{
+				ApiUrl:     "https://mail.stark.com",
+				HttpClient: http.DefaultClient,
+			},
+		},
+		{
+			name:    "base url overrides client options",
+			options: ClientOptions{ApiUrl: "https://google.com"},
+			opts:    []Option{WithBaseURL("https://mail.stark.com")},
+			want: &Client{
+				ApiUrl:     "https://mail.stark.com",
+				HttpClient: http.DefaultClient,
+			},
+		},
+		{
+			name: "http client",
+			opts: []Option{WithHTTPClient(customHttpClient)},
+			want: &Client{
+				ApiUrl:     "https://api.forwardemail.net",
+				HttpClient: customHttpClient,
+			},
+		},
+		{
+			name: "timeout",
+			opts: []Option{WithTimeout(5 * time.Second)},
+			want: &Client{
+				ApiUrl:     "https://api.forwardemail.net",
+				HttpClient: &http.Client{Timeout: 5 * time.Second},
+			},
+		},
+		{
+			name: "user agent and headers",
+			opts: []Option{
+				WithUserAgent("jarvis/1.0"),
+				WithHeader("X-Tenant", "stark"),
+				WithHeader("X-Tenant", "industries"),
+			},
+			want: &Client{
+				ApiUrl:     "https://api.forwardemail.net",
+				UserAgent:  "jarvis/1.0",
+				Headers:    http.Header{"X-Tenant": {"stark", "industries"}},
+				HttpClient: http.DefaultClient,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewClient(tt.options, tt.opts...)
+			if diff := cmp.Diff(tt.want.ApiUrl, got.ApiUrl); diff != "" {
+				t.Fatalf("api urls are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.want.UserAgent, got.UserAgent); diff != "" {
+				t.Fatalf("user agents are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.want.Headers, got.Headers); diff != "" {
+				t.Fatalf("headers are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.want.HttpClient.Timeout, got.HttpClient.Timeout); diff != "" {
+				t.Fatalf("timeouts are not the same %s", diff)
+			}
+		})
+	}
+
+	if http.DefaultClient.Timeout != 0 {
+		t.Fatalf("http.DefaultClient was modified")
+	}
+}
+
+func TestClient_Headers(t *testing.T) {
+	var got http.Header
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{}, WithBaseURL(svr.URL), WithUserAgent("jarvis/1.0"), WithHeader("X-Tenant", "stark"))
+
+	_, _ = c.GetAccount()
+	if diff := cmp.Diff("jarvis/1.0", got.Get("User-Agent")); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+	if diff := cmp.Diff("stark", got.Get("X-Tenant")); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestClient_Hooks(t *testing.T) {
+	var calls int
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		fmt.Fprint(w, r.Header.Get("X-Trace-Id"))
+	}))
+	defer svr.Close()
+
+	var events []string
+	c := NewClient(
+		ClientOptions{
+			RetryPolicy: &RetryPolicy{MaxAttempts: 2},
+		},
+		WithBaseURL(svr.URL),
+		WithRequestHook(func(r *http.Request) {
+			r.Header.Set("X-Trace-Id", "abc")
+			events = append(events, "request "+r.Method+" "+r.URL.Path)
+		}),
+		WithResponseHook(func(r *http.Response) {
+			events = append(events, fmt.Sprintf("response %d", r.StatusCode))
+		}),
+	)
+
+	res, err := c.doRequest(mustNewRequest(t, c, "GET", "/v1/account"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff("abc", string(res)); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	want := []string{"request GET /v1/account", "response 503", "request GET /v1/account", "response 200"}
+	if diff := cmp.Diff(want, events); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func mustNewRequest(t *testing.T, c *Client, method, path string) *http.Request {
+	req, err := c.NewRequest(method, path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return req
+}
+
+func TestClient_RequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		fmt.Fprint(w, `{"name": "james"}`)
+	}))
+	defer svr.Close()
+	defer close(release)
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	_, err := c.GetAlias("stark.com", "james", WithRequestTimeout(50*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+
+	_, err = c.GetAlias("stark.com", "james", WithRequestDeadline(time.Now().Add(-time.Second)))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+}
+
+func Test_requestContext(t *testing.T) {
+	ctx, cancel := requestContext(nil)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected no deadline without options")
+	}
+
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel = requestContext([]RequestOption{WithRequestDeadline(deadline), WithRequestTimeout(time.Hour)})
+	defer cancel()
+	if got, _ := ctx.Deadline(); !got.Equal(deadline) {
+		t.Errorf("expected the earliest deadline %v, got %v", deadline, got)
+	}
+}
+
+func TestClient_DownloadLogs_RequestTimeout(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "id,message\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	body, err := c.DownloadLogs("stark.com", LogFilters{}, WithRequestTimeout(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The context outlives the call so the body can still be read, and is released on Close.
+	buf := make([]byte, 11)
+	if _, err := io.ReadFull(body, buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := body.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
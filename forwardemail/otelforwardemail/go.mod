@@ -0,0 +1,25 @@
+module github.com/mattwebbio/go-forwardemail/forwardemail/otelforwardemail
+
+go 1.21
+
+require (
+	github.com/google/go-cmp v0.6.0
+	github.com/mattwebbio/go-forwardemail v0.0.0
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/metric v1.28.0
+	go.opentelemetry.io/otel/sdk v1.28.0
+	go.opentelemetry.io/otel/sdk/metric v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	golang.org/x/net v0.35.0 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+	golang.org/x/text v0.22.0 // indirect
+)
+
+// The package is developed alongside the client it instruments.
+replace github.com/mattwebbio/go-forwardemail => ../..
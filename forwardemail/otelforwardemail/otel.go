@@ -0,0 +1,139 @@
+// Package otelforwardemail instruments a forwardemail.Client with OpenTelemetry
+// traces and metrics. It is a module of its own, so that only the programs using it
+// depend on OpenTelemetry.
+package otelforwardemail
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mattwebbio/go-forwardemail/forwardemail"
+)
+
+const instrumentationName = "github.com/mattwebbio/go-forwardemail/forwardemail/otelforwardemail"
+
+// WithTracing creates a client span for every request sent to the API, retries included.
+// Spans are named after the method and endpoint, e.g. "GET /v1/domains/{domain}/aliases",
+// and carry the domain, status code and resend count. It wraps the transport of the
+// HTTP client in use, so it must come after forwardemail.WithHTTPClient.
+func WithTracing(tp trace.TracerProvider) forwardemail.Option {
+	return func(c *forwardemail.Client) {
+		wrapTransport(c, func(t *transport) {
+			t.tracer = tp.Tracer(instrumentationName)
+		})
+	}
+}
+
+// WithMetrics records the number of requests sent to the API and their duration.
+// Like WithTracing, it must come after forwardemail.WithHTTPClient.
+func WithMetrics(mp metric.MeterProvider) forwardemail.Option {
+	return func(c *forwardemail.Client) {
+		meter := mp.Meter(instrumentationName)
+		wrapTransport(c, func(t *transport) {
+			// Instrument creation only fails on invalid names, which are constants here.
+			t.requests, _ = meter.Int64Counter("forwardemail.client.requests",
+				metric.WithDescription("Number of requests sent to the Forward Email API."),
+				metric.WithUnit("{request}"))
+			t.duration, _ = meter.Float64Histogram("forwardemail.client.request.duration",
+				metric.WithDescription("Duration of the requests sent to the Forward Email API."),
+				metric.WithUnit("s"))
+		})
+	}
+}
+
+// wrapTransport installs the instrumented transport once and lets configure fill it in,
+// so WithTracing and WithMetrics can be combined. The HTTP client is copied to leave a
+// shared client such as http.DefaultClient untouched.
+func wrapTransport(c *forwardemail.Client, configure func(*transport)) {
+	httpClient := *c.HttpClient
+	t, ok := httpClient.Transport.(*transport)
+	if ok {
+		copied := *t
+		t = &copied
+	} else {
+		t = &transport{base: httpClient.Transport}
+		if t.base == nil {
+			t.base = http.DefaultTransport
+		}
+	}
+
+	configure(t)
+	httpClient.Transport = t
+	c.HttpClient = &httpClient
+}
+
+type transport struct {
+	base     http.RoundTripper
+	tracer   trace.Tracer
+	requests metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint, domain := forwardemail.Route(req.URL.EscapedPath())
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", req.Method),
+		attribute.String("forwardemail.endpoint", endpoint),
+	}
+
+	ctx := req.Context()
+	var span trace.Span
+	if t.tracer != nil {
+		spanAttrs := append([]attribute.KeyValue{}, attrs...)
+		if domain != "" {
+			spanAttrs = append(spanAttrs, attribute.String("forwardemail.domain", domain))
+		}
+		if attempt := forwardemail.Attempt(ctx); attempt > 1 {
+			spanAttrs = append(spanAttrs, attribute.Int("http.request.resend_count", attempt-1))
+		}
+
+		ctx, span = t.tracer.Start(ctx, req.Method+" "+endpoint,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(spanAttrs...))
+		defer span.End()
+		req = req.WithContext(ctx)
+	}
+
+	start := time.Now()
+	res, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		attrs = append(attrs, attribute.String("error.type", "transport"))
+		if span != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+	} else {
+		attrs = append(attrs, attribute.Int("http.response.status_code", res.StatusCode))
+		if span != nil {
+			span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+			if res.StatusCode >= 400 {
+				span.SetStatus(codes.Error, strconv.Itoa(res.StatusCode))
+			}
+		}
+	}
+
+	if t.requests != nil {
+		t.requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+	if t.duration != nil {
+		t.duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(attrs...))
+	}
+
+	return res, err
+}
+
+// CloseIdleConnections lets forwardemail.Client.CloseIdleConnections reach the transport
+// being wrapped.
+func (t *transport) CloseIdleConnections() {
+	if closer, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
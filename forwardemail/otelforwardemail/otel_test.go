@@ -0,0 +1,127 @@
+package otelforwardemail
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/mattwebbio/go-forwardemail/forwardemail"
+)
+
+func TestWithTracing(t *testing.T) {
+	calls := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		fmt.Fprint(w, `{"name": "tony"}`)
+	}))
+	defer svr.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	c := forwardemail.NewClient(forwardemail.ClientOptions{
+		ApiUrl:      svr.URL,
+		RetryPolicy: &forwardemail.RetryPolicy{MaxAttempts: 2},
+	}, WithTracing(tp), WithMetrics(mp))
+
+	if _, err := c.GetAlias("stark.com", "tony"); err != nil {
+		t.Fatal(err)
+	}
+
+	type span struct {
+		Name  string
+		Attrs map[attribute.Key]attribute.Value
+	}
+
+	var got []span
+	for _, s := range recorder.Ended() {
+		attrs := map[attribute.Key]attribute.Value{}
+		for _, kv := range s.Attributes() {
+			attrs[kv.Key] = kv.Value
+		}
+		got = append(got, span{Name: s.Name(), Attrs: attrs})
+	}
+
+	want := []span{
+		{
+			Name: "GET /v1/domains/{domain}/aliases/{alias}",
+			Attrs: map[attribute.Key]attribute.Value{
+				"http.request.method":       attribute.StringValue("GET"),
+				"forwardemail.endpoint":     attribute.StringValue("/v1/domains/{domain}/aliases/{alias}"),
+				"forwardemail.domain":       attribute.StringValue("stark.com"),
+				"http.response.status_code": attribute.IntValue(503),
+			},
+		},
+		{
+			Name: "GET /v1/domains/{domain}/aliases/{alias}",
+			Attrs: map[attribute.Key]attribute.Value{
+				"http.request.method":       attribute.StringValue("GET"),
+				"forwardemail.endpoint":     attribute.StringValue("/v1/domains/{domain}/aliases/{alias}"),
+				"forwardemail.domain":       attribute.StringValue("stark.com"),
+				"http.request.resend_count": attribute.IntValue(1),
+				"http.response.status_code": attribute.IntValue(200),
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(attribute.Value{})); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	counts := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					counts[m.Name] += dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					counts[m.Name] += int64(dp.Count)
+				}
+			}
+		}
+	}
+
+	wantCounts := map[string]int64{
+		"forwardemail.client.requests":         2,
+		"forwardemail.client.request.duration": 2,
+	}
+	if diff := cmp.Diff(wantCounts, counts); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestWithTracing_KeepsHTTPClient(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+
+	c := forwardemail.NewClient(forwardemail.ClientOptions{}, WithTracing(tp))
+	if c.HttpClient == http.DefaultClient {
+		t.Fatal("http.DefaultClient was modified")
+	}
+	if http.DefaultClient.Transport != nil {
+		t.Fatal("http.DefaultClient transport was replaced")
+	}
+}
@@ -0,0 +1,170 @@
+package forwardemail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Domain plans, for DomainParameters.Plan and ChangeDomainPlan.
+const (
+	PlanFree               = "free"
+	PlanEnhancedProtection = "enhanced_protection"
+	PlanTeam               = "team"
+)
+
+// Member groups, for InviteDomainMember and UpdateDomainMember.
+const (
+	GroupAdmin = "admin"
+	GroupUser  = "user"
+)
+
+// PlanError is returned when the API refuses an operation because the plan of the account or
+// of the domain doesn't include it, e.g. members on a free domain. It wraps the *APIError.
+type PlanError struct {
+	Domain string
+	// Plan is the plan that was asked for, if any.
+	Plan string
+	Err  *APIError
+}
+
+func (e *PlanError) Error() string {
+	if e.Plan != "" {
+		return fmt.Sprintf("domain %s can't use the %s plan: %s", e.Domain, e.Plan, e.Err.Message)
+	}
+
+	return fmt.Sprintf("the plan of domain %s doesn't allow this: %s", e.Domain, e.Err.Message)
+}
+
+func (e *PlanError) Unwrap() error {
+	return e.Err
+}
+
+// asPlanError turns the 402 Payment Required answers of the API into a *PlanError.
+func asPlanError(err error, domain, plan string) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusPaymentRequired {
+		return &PlanError{Domain: domain, Plan: plan, Err: apiErr}
+	}
+
+	return err
+}
+
+// ChangeDomainPlan moves a domain to another plan, one of PlanFree, PlanEnhancedProtection
+// or PlanTeam; switching to PlanTeam turns it into a team domain, which can have members.
+// When the account doesn't have the plan, the error is a *PlanError. Global domains, shared
+// between the users of Forward Email, are managed by its staff and can't be converted.
+func (c *Client) ChangeDomainPlan(name string, plan string, opts ...RequestOption) (*Domain, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.ChangeDomainPlanContext(ctx, name, plan)
+}
+
+func (c *Client) ChangeDomainPlanContext(ctx context.Context, name string, plan string) (*Domain, error) {
+	switch plan {
+	case PlanFree, PlanEnhancedProtection, PlanTeam:
+	default:
+		return nil, fmt.Errorf("unknown plan %q", plan)
+	}
+
+	params := url.Values{}
+	params.Add("plan", plan)
+
+	item, err := do[Domain](ctx, c, "PUT", pathf("/v1/domains/%s", name), params)
+	if err != nil {
+		return nil, asPlanError(err, name, plan)
+	}
+
+	return item, nil
+}
+
+// TransferParameters describe the new owner of a domain for TransferDomain.
+type TransferParameters struct {
+	// Email is the email address of the account to hand the domain over to.
+	Email string
+	// KeepAccess keeps the current account as an admin of the domain. Otherwise it leaves
+	// the domain once the new owner is an admin.
+	KeepAccess bool
+}
+
+// DomainTransfer is the outcome of TransferDomain.
+type DomainTransfer struct {
+	Domain *Domain
+	// Invited is true when the new owner wasn't a member yet and has been invited as an admin
+	// instead. The transfer is only complete once they accept the invite, after which
+	// TransferDomain can be called again to leave the domain.
+	Invited bool
+}
+
+// TransferDomain hands a domain over to another account, which needs the domain to be on
+// the team plan. The API has no transfer endpoint, so the new owner is made an admin, or
+// invited as one when not a member yet, and the current account then leaves the domain
+// unless KeepAccess is set. Members are matched by email, which needs the API to expand the
+// users of the domain members. When the plan of the domain doesn't allow members, the error
+// is a *PlanError.
+func (c *Client) TransferDomain(name string, parameters TransferParameters, opts ...RequestOption) (*DomainTransfer, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.TransferDomainContext(ctx, name, parameters)
+}
+
+func (c *Client) TransferDomainContext(ctx context.Context, name string, parameters TransferParameters) (*DomainTransfer, error) {
+	if parameters.Email == "" {
+		return nil, errors.New("the email of the new owner is required")
+	}
+
+	domain, err := c.GetDomainContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := findMemberByEmail(domain.Members, parameters.Email)
+	if owner == nil {
+		domain, err = c.InviteDomainMemberContext(ctx, name, parameters.Email, GroupAdmin)
+		if err != nil {
+			return nil, asPlanError(err, name, "")
+		}
+
+		return &DomainTransfer{Domain: domain, Invited: true}, nil
+	}
+
+	if owner.Group != GroupAdmin {
+		domain, err = c.UpdateDomainMemberContext(ctx, name, owner.User.ID, GroupAdmin)
+		if err != nil {
+			return nil, asPlanError(err, name, "")
+		}
+	}
+
+	if parameters.KeepAccess {
+		return &DomainTransfer{Domain: domain}, nil
+	}
+
+	account, err := c.GetAccountContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if account.Id == owner.User.ID {
+		return &DomainTransfer{Domain: domain}, nil
+	}
+
+	if err := c.RemoveDomainMemberContext(ctx, name, account.Id); err != nil {
+		return nil, err
+	}
+
+	return &DomainTransfer{Domain: domain}, nil
+}
+
+func findMemberByEmail(members []Member, email string) *Member {
+	for i, member := range members {
+		if member.User.Account != nil && strings.EqualFold(member.User.Account.Email, email) {
+			return &members[i]
+		}
+	}
+
+	return nil
+}
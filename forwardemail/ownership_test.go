@@ -0,0 +1,149 @@
+package forwardemail
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_ChangeDomainPlan(t *testing.T) {
+	tests := []struct {
+		name          string
+		plan          string
+		status        int
+		wantPlanError bool
+		wantErr       bool
+	}{
+		{name: "upgrade", plan: PlanTeam, status: http.StatusOK},
+		{name: "plan not paid", plan: PlanTeam, status: http.StatusPaymentRequired, wantPlanError: true, wantErr: true},
+		{name: "unknown plan", plan: "platinum", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var form string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.ParseForm()
+				form = r.Method + " " + r.URL.Path + " " + r.PostForm.Encode()
+
+				w.WriteHeader(tt.status)
+				if tt.status != http.StatusOK {
+					fmt.Fprint(w, `{"message": "Please upgrade to the Team plan."}`)
+					return
+				}
+				fmt.Fprint(w, `{"name": "stark.com", "plan": "team"}`)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, err := c.ChangeDomainPlan("stark.com", tt.plan)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			var planErr *PlanError
+			if errors.As(err, &planErr) != tt.wantPlanError {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got.Plan != PlanTeam {
+				t.Errorf("unexpected domain %+v", got)
+			}
+			if diff := cmp.Diff("PUT /v1/domains/stark.com plan=team", form); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_TransferDomain(t *testing.T) {
+	domain := `{
+		"name": "stark.com",
+		"members": [
+			{"user": {"id": "me", "email": "tony@stark.com"}, "group": "admin"},
+			{"user": {"id": "pepper", "email": "pepper@stark.com"}, "group": "user"}
+		]
+	}`
+
+	tests := []struct {
+		name        string
+		parameters  TransferParameters
+		wantCalls   []string
+		wantInvited bool
+	}{
+		{
+			name:       "existing member",
+			parameters: TransferParameters{Email: "Pepper@stark.com"},
+			wantCalls: []string{
+				"GET /v1/domains/stark.com",
+				"PUT /v1/domains/stark.com/members/pepper group=admin",
+				"GET /v1/account",
+				"DELETE /v1/domains/stark.com/members/me",
+			},
+		},
+		{
+			name:       "keeping access",
+			parameters: TransferParameters{Email: "pepper@stark.com", KeepAccess: true},
+			wantCalls: []string{
+				"GET /v1/domains/stark.com",
+				"PUT /v1/domains/stark.com/members/pepper group=admin",
+			},
+		},
+		{
+			name:       "new member",
+			parameters: TransferParameters{Email: "happy@stark.com"},
+			wantCalls: []string{
+				"GET /v1/domains/stark.com",
+				"POST /v1/domains/stark.com/invites email=happy%40stark.com&group=admin",
+			},
+			wantInvited: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.ParseForm()
+				call := r.Method + " " + r.URL.Path
+				if len(r.PostForm) > 0 {
+					call += " " + r.PostForm.Encode()
+				}
+				calls = append(calls, call)
+
+				switch r.URL.Path {
+				case "/v1/account":
+					fmt.Fprint(w, `{"id": "me", "email": "tony@stark.com"}`)
+				default:
+					fmt.Fprint(w, domain)
+				}
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, err := c.TransferDomain("stark.com", tt.parameters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Invited != tt.wantInvited {
+				t.Errorf("expected Invited to be %v", tt.wantInvited)
+			}
+			if diff := cmp.Diff(tt.wantCalls, calls); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
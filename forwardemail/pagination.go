@@ -0,0 +1,93 @@
+package forwardemail
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ListOptions controls paging, sorting and searching on list endpoints.
+// Zero values are left out of the request so the API defaults apply.
+type ListOptions struct {
+	Page   int
+	Limit  int
+	Sort   string
+	Search string
+}
+
+// Pagination is read from the X-Page-* and X-Item-Count headers of list responses.
+type Pagination struct {
+	PageCount   int
+	CurrentPage int
+	PageSize    int
+	ItemCount   int
+}
+
+func (o ListOptions) values() url.Values {
+	params := url.Values{}
+	if o.Page > 0 {
+		params.Add("page", strconv.Itoa(o.Page))
+	}
+	if o.Limit > 0 {
+		params.Add("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Sort != "" {
+		params.Add("sort", o.Sort)
+	}
+	if o.Search != "" {
+		params.Add("q", o.Search)
+	}
+
+	return params
+}
+
+// sortValue is the sort parameter for a field in the given order: the API sorts in
+// descending order on fields prefixed with a dash.
+func sortValue(field, order string) string {
+	if strings.EqualFold(order, "desc") {
+		return "-" + field
+	}
+
+	return field
+}
+
+func parsePagination(header http.Header) *Pagination {
+	atoi := func(key string) int {
+		v, _ := strconv.Atoi(header.Get(key))
+		return v
+	}
+
+	return &Pagination{
+		PageCount:   atoi("X-Page-Count"),
+		CurrentPage: atoi("X-Page-Current"),
+		PageSize:    atoi("X-Page-Size"),
+		ItemCount:   atoi("X-Item-Count"),
+	}
+}
+
+// HasNextPage reports whether there are more pages after the current one.
+func (p *Pagination) HasNextPage() bool {
+	return p != nil && p.CurrentPage > 0 && p.CurrentPage < p.PageCount
+}
+
+// collectPages keeps calling fetch with increasing page numbers until the API reports no more pages.
+func collectPages[T any](first int, fetch func(page int) ([]T, *Pagination, error)) ([]T, error) {
+	if first < 1 {
+		first = 1
+	}
+
+	var all []T
+	for page := first; ; page++ {
+		items, pagination, err := fetch(page)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, items...)
+
+		if len(items) == 0 || !pagination.HasNextPage() {
+			return all, nil
+		}
+	}
+}
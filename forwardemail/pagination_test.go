@@ -0,0 +1,140 @@
+package forwardemail
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListOptions_values(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ListOptions
+		want    string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "everything at once",
+			options: ListOptions{
+				Page:   2,
+				Limit:  50,
+				Sort:   "-created_at",
+				Search: "stark",
+			},
+			want: "limit=50&page=2&q=stark&sort=-created_at",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.options.values().Encode()
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_GetDomainsPage(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Page-Count", "3")
+		w.Header().Set("X-Page-Current", "2")
+		w.Header().Set("X-Page-Size", "1")
+		w.Header().Set("X-Item-Count", "3")
+		fmt.Fprintf(w, `[{"name": "stark.com"}]`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	items, pagination, err := c.GetDomainsPage(ListDomainParameters{ListOptions: ListOptions{Page: 2, Limit: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]Domain{{Name: "stark.com"}}, items); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	want := &Pagination{PageCount: 3, CurrentPage: 2, PageSize: 1, ItemCount: 3}
+	if diff := cmp.Diff(want, pagination); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestClient_GetAllAliases(t *testing.T) {
+	tests := []struct {
+		name      string
+		pageCount int
+		want      []Alias
+	}{
+		{
+			name:      "single page",
+			pageCount: 1,
+			want:      []Alias{{Name: "alias-1"}},
+		},
+		{
+			name:      "several pages",
+			pageCount: 3,
+			want:      []Alias{{Name: "alias-1"}, {Name: "alias-2"}, {Name: "alias-3"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+				w.Header().Set("X-Page-Count", strconv.Itoa(tt.pageCount))
+				w.Header().Set("X-Page-Current", strconv.Itoa(page))
+				fmt.Fprintf(w, `[{"name": "alias-%d"}]`, page)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, err := c.GetAllAliases("stark.com", ListAliasParameters{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_GetAllDomains(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+		w.Header().Set("X-Page-Count", "2")
+		w.Header().Set("X-Page-Current", strconv.Itoa(page))
+		fmt.Fprintf(w, `[{"name": "domain-%d.com"}]`, page)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	got, err := c.GetAllDomains(ListDomainParameters{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Domain{{Name: "domain-1.com"}, {Name: "domain-2.com"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
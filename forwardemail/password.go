@@ -0,0 +1,60 @@
+package forwardemail
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// Character sets for PasswordPolicy.Charset.
+const (
+	CharsetAlphanumeric = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	CharsetSymbols      = "!#$%&()*+,-./:;<=>?@[]^_{|}~"
+)
+
+// DefaultPasswordLength is the length of the passwords generated by GeneratePassword when
+// the policy doesn't set one.
+const DefaultPasswordLength = 32
+
+// PasswordPolicy describes the passwords generated by GeneratePassword.
+type PasswordPolicy struct {
+	// Length is the number of characters, DefaultPasswordLength when zero.
+	Length int
+	// Charset lists the characters to pick from, CharsetAlphanumeric when empty. Combine the
+	// character sets to include symbols: CharsetAlphanumeric + CharsetSymbols.
+	Charset string
+}
+
+// GeneratePassword returns a password following policy, each character picked uniformly
+// from the character set with crypto/rand.
+func GeneratePassword(policy PasswordPolicy) (string, error) {
+	length := policy.Length
+	if length == 0 {
+		length = DefaultPasswordLength
+	}
+	if length < 0 {
+		return "", errors.New("the password length must not be negative")
+	}
+
+	charset := policy.Charset
+	if charset == "" {
+		charset = CharsetAlphanumeric
+	}
+	characters := []rune(charset)
+	if len(characters) < 2 {
+		return "", errors.New("the password character set needs at least two characters")
+	}
+
+	var password strings.Builder
+	size := big.NewInt(int64(len(characters)))
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", err
+		}
+		password.WriteRune(characters[n.Int64()])
+	}
+
+	return password.String(), nil
+}
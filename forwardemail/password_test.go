@@ -0,0 +1,81 @@
+package forwardemail
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     PasswordPolicy
+		wantLength int
+		wantChars  string
+		wantErr    bool
+	}{
+		{name: "defaults", wantLength: DefaultPasswordLength, wantChars: CharsetAlphanumeric},
+		{name: "symbols", policy: PasswordPolicy{Length: 64, Charset: CharsetAlphanumeric + CharsetSymbols}, wantLength: 64, wantChars: CharsetAlphanumeric + CharsetSymbols},
+		{name: "non-ASCII charset", policy: PasswordPolicy{Length: 8, Charset: "éàü"}, wantLength: 8, wantChars: "éàü"},
+		{name: "negative length", policy: PasswordPolicy{Length: -1}, wantErr: true},
+		{name: "single character", policy: PasswordPolicy{Charset: "a"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GeneratePassword(tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if n := utf8.RuneCountInString(got); n != tt.wantLength {
+				t.Errorf("expected %d characters, got %d", tt.wantLength, n)
+			}
+			for _, r := range got {
+				if !strings.ContainsRune(tt.wantChars, r) {
+					t.Errorf("unexpected character %q in %q", r, got)
+				}
+			}
+		})
+	}
+
+	a, _ := GeneratePassword(PasswordPolicy{})
+	b, _ := GeneratePassword(PasswordPolicy{})
+	if a == b {
+		t.Errorf("expected different passwords, got %q twice", a)
+	}
+}
+
+func TestClient_GenerateAliasPassword_LocalPassword(t *testing.T) {
+	var sent string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		sent = r.PostForm.Get("new_password")
+		fmt.Fprint(w, `{"username": "tony@stark.com"}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	got, err := c.GenerateAliasPassword("stark.com", "tony", GeneratePasswordParameters{
+		LocalPassword: &PasswordPolicy{Length: 40},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sent) != 40 {
+		t.Errorf("expected a 40 characters password to be sent, got %q", sent)
+	}
+	if got.Password != sent {
+		t.Errorf("expected the sent password to be returned, got %q", got.Password)
+	}
+}
@@ -0,0 +1,24 @@
+package forwardemail
+
+import "context"
+
+// ProvisionOptions controls how long ProvisionDomain waits for the DNS records to propagate.
+type ProvisionOptions = WaitOptions
+
+// ProvisionDomain creates a domain, then keeps verifying its DNS records until they have
+// propagated or the timeout is reached, and returns the verified domain. The DNS records to
+// publish can be obtained with Domain.RequiredDNSRecords on the result of CreateDomain.
+func (c *Client) ProvisionDomain(name string, parameters DomainParameters, options ProvisionOptions, opts ...RequestOption) (*Domain, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.ProvisionDomainContext(ctx, name, parameters, options)
+}
+
+func (c *Client) ProvisionDomainContext(ctx context.Context, name string, parameters DomainParameters, options ProvisionOptions) (*Domain, error) {
+	if _, err := c.CreateDomainContext(ctx, name, parameters); err != nil {
+		return nil, err
+	}
+
+	return c.WaitForDomainVerifiedContext(ctx, name, options)
+}
@@ -0,0 +1,86 @@
+package forwardemail
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_ProvisionDomain(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		wantErr  bool
+	}{
+		{
+			name:     "verified after propagation",
+			failures: 2,
+		},
+		{
+			name:     "never verified",
+			failures: 1000,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			verifies := 0
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+
+				switch r.URL.Path {
+				case "/v1/domains/stark.com/verify-records":
+					verifies++
+					if verifies <= tt.failures {
+						w.WriteHeader(http.StatusBadRequest)
+						fmt.Fprint(w, `{"message": "Domain's DNS TXT record is not configured."}`)
+						return
+					}
+					fmt.Fprint(w, `"Domain's DNS records have been verified."`)
+				default:
+					fmt.Fprint(w, `{"name": "stark.com", "has_mx_record": true, "has_txt_record": true}`)
+				}
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, err := c.ProvisionDomain("stark.com", DomainParameters{}, ProvisionOptions{
+				Timeout:     50 * time.Millisecond,
+				Interval:    time.Millisecond,
+				MaxInterval: 2 * time.Millisecond,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if verifies < 2 {
+					t.Fatalf("expected several verification attempts, got %d", verifies)
+				}
+				return
+			}
+
+			want := []string{
+				"POST /v1/domains",
+				"GET /v1/domains/stark.com/verify-records",
+				"GET /v1/domains/stark.com/verify-records",
+				"GET /v1/domains/stark.com/verify-records",
+				"GET /v1/domains/stark.com",
+			}
+			if diff := cmp.Diff(want, calls); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+			if !got.HasTxtRecord {
+				t.Fatalf("unexpected domain %+v", got)
+			}
+		})
+	}
+}
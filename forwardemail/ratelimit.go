@@ -0,0 +1,143 @@
+package forwardemail
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit is the rate limit state reported by the X-RateLimit-* response headers.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// parseRateLimit returns false when the response carries no rate limit headers.
+func parseRateLimit(header http.Header) (RateLimit, bool) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	rl := RateLimit{Limit: limit}
+	rl.Remaining, _ = strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rl.Reset = time.Unix(reset, 0)
+	}
+
+	return rl, true
+}
+
+// RateLimit returns the rate limit state seen on the latest response, and false
+// when no response with rate limit headers has been received yet.
+func (c *Client) RateLimit() (RateLimit, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.rateLimit == nil {
+		return RateLimit{}, false
+	}
+
+	return *c.rateLimit, true
+}
+
+func (c *Client) updateRateLimit(res *http.Response) {
+	rl, ok := parseRateLimit(res.Header)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	c.rateLimit = &rl
+	callback := c.rateLimitCallback
+	c.mu.Unlock()
+
+	if callback != nil {
+		callback(rl)
+	}
+}
+
+// WithRateLimitCallback registers a function called with the rate limit state of every
+// response that reports one, so long-running jobs can pace themselves.
+func WithRateLimitCallback(callback func(RateLimit)) Option {
+	return func(c *Client) {
+		c.rateLimitCallback = callback
+	}
+}
+
+// WithRateLimit throttles the requests sent by the client, across all goroutines, to rps per
+// second on average with bursts of up to burst requests, so bulk operations stay under the
+// per-key limits of the API. Every attempt of a retried request counts, and requests served
+// from the cache or in dry run don't. A request waiting for its turn fails with the error of
+// its context when it ends. A burst below 1 is treated as 1, and rps <= 0 removes the limit.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Client) {
+		if rps <= 0 {
+			c.limiter = nil
+			return
+		}
+
+		c.limiter = &tokenBucket{rate: rps, burst: float64(max(burst, 1))}
+	}
+}
+
+// tokenBucket hands out tokens at rate per second, holding up to burst of them. Tokens are
+// reserved ahead, letting the count go negative, so waiting callers are served in order.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// reserve takes a token and returns how long to wait before using it.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case b.last.IsZero():
+		b.tokens = b.burst
+		b.last = now
+	case now.After(b.last):
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// release gives back a token reserved by a request that was never sent.
+func (b *tokenBucket) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.burst, b.tokens+1)
+}
+
+// waitRateLimit blocks until the rate limit of the client lets a request out.
+func (c *Client) waitRateLimit(ctx context.Context) error {
+	if c.limiter == nil {
+		return nil
+	}
+
+	wait := c.limiter.reserve(c.clock.Now())
+	if wait <= 0 {
+		return nil
+	}
+	if err := c.clock.Sleep(ctx, wait); err != nil {
+		c.limiter.release()
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package forwardemail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_RateLimit(t *testing.T) {
+	var withHeaders bool
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if withHeaders {
+			w.Header().Set("X-RateLimit-Limit", "1000")
+			w.Header().Set("X-RateLimit-Remaining", "998")
+			w.Header().Set("X-RateLimit-Reset", "1696968766")
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	var seen []RateLimit
+	c := NewClient(ClientOptions{}, WithBaseURL(svr.URL), WithRateLimitCallback(func(rl RateLimit) {
+		seen = append(seen, rl)
+	}))
+
+	_, _ = c.GetAccount()
+	if _, ok := c.RateLimit(); ok {
+		t.Fatalf("expected no rate limit before any header was seen")
+	}
+
+	withHeaders = true
+	_, _ = c.GetAccount()
+
+	want := RateLimit{Limit: 1000, Remaining: 998, Reset: time.Unix(1696968766, 0)}
+
+	got, ok := c.RateLimit()
+	if !ok {
+		t.Fatalf("expected a rate limit")
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+	if diff := cmp.Diff([]RateLimit{want}, seen); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	start := time.Unix(1696968766, 0)
+	b := &tokenBucket{rate: 10, burst: 2}
+
+	tests := []struct {
+		after time.Duration
+		want  time.Duration
+	}{
+		{after: 0, want: 0},
+		{after: 0, want: 0},
+		{after: 0, want: 100 * time.Millisecond},
+		{after: 0, want: 200 * time.Millisecond},
+		{after: time.Second, want: 0},
+		{after: time.Second, want: 0},
+		{after: time.Second, want: 100 * time.Millisecond},
+	}
+
+	var got []time.Duration
+	var want []time.Duration
+	for _, tt := range tests {
+		got = append(got, b.reserve(start.Add(tt.after)))
+		want = append(want, tt.want)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+}
+
+// sleepRecorder is a Clock whose Sleep returns right away, moving the time forward.
+type sleepRecorder struct {
+	mu    sync.Mutex
+	now   time.Time
+	slept []time.Duration
+}
+
+func (c *sleepRecorder) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *sleepRecorder) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.slept = append(c.slept, d)
+	c.now = c.now.Add(d)
+
+	return nil
+}
+
+func TestWithRateLimit(t *testing.T) {
+	var hits atomic.Int32
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if hits.Load() == 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	clock := &sleepRecorder{now: time.Unix(1696968766, 0)}
+	c := NewClient(ClientOptions{
+		ApiUrl:      svr.URL,
+		RetryPolicy: &RetryPolicy{MaxAttempts: 2},
+	}, WithClock(clock), WithRateLimit(2, 2))
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetAccount(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := hits.Load(); got != 4 {
+		t.Errorf("expected 4 requests, got %d", got)
+	}
+
+	// The third request is past the burst, and its retry waits for a token of its own.
+	var waits []time.Duration
+	for _, d := range clock.slept {
+		if d > 0 {
+			waits = append(waits, d)
+		}
+	}
+	if diff := cmp.Diff([]time.Duration{500 * time.Millisecond, 500 * time.Millisecond}, waits); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+}
+
+func TestWithRateLimit_Concurrent(t *testing.T) {
+	var hits atomic.Int32
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	}, WithRateLimit(50, 1))
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = c.GetAccount()
+		}()
+	}
+	wg.Wait()
+
+	if got := hits.Load(); got != 5 {
+		t.Errorf("expected 5 requests, got %d", got)
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("expected 5 requests at 50 per second to take at least 80ms, took %s", elapsed)
+	}
+}
+
+func TestWithRateLimit_ContextDone(t *testing.T) {
+	var hits atomic.Int32
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	}, WithRateLimit(0.1, 1))
+
+	if _, err := c.GetAccount(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := c.GetAccount(WithRequestTimeout(20 * time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected the throttled request not to be sent, got %d requests", got)
+	}
+}
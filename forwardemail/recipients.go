@@ -0,0 +1,168 @@
+package forwardemail
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// RecipientKind is the kind of destination an alias forwards to, which decides how the API delivers to it.
+type RecipientKind int
+
+const (
+	// RecipientUnknown is a value the API would not accept as a recipient.
+	RecipientUnknown RecipientKind = iota
+	// RecipientEmail forwards to an email address.
+	RecipientEmail
+	// RecipientWebhook POSTs the parsed message to an http or https URL.
+	RecipientWebhook
+	// RecipientFQDN forwards to the mail server of a domain name, keeping the original recipient address.
+	RecipientFQDN
+	// RecipientIP forwards to the mail server at an IP address, keeping the original recipient address.
+	RecipientIP
+)
+
+func (k RecipientKind) String() string {
+	switch k {
+	case RecipientEmail:
+		return "email"
+	case RecipientWebhook:
+		return "webhook"
+	case RecipientFQDN:
+		return "fqdn"
+	case RecipientIP:
+		return "ip"
+	default:
+		return "unknown"
+	}
+}
+
+// Recipient is a typed alias recipient. It is encoded to and decoded from JSON as the plain
+// string the API uses; decoding never fails, unrecognized values get the RecipientUnknown kind.
+type Recipient struct {
+	Kind  RecipientKind
+	Value string
+}
+
+// ParseRecipient returns the recipient for s, or an error when s is not an email address,
+// webhook URL, IP address or fully qualified domain name.
+func ParseRecipient(s string) (Recipient, error) {
+	r := Recipient{Kind: recipientKind(s), Value: s}
+	if r.Kind == RecipientUnknown {
+		return r, fmt.Errorf("%q is not an email address, webhook URL, IP address or domain name", s)
+	}
+
+	return r, nil
+}
+
+// ParseRecipients parses every recipient in values, failing on the first invalid one.
+func ParseRecipients(values []string) ([]Recipient, error) {
+	recipients := make([]Recipient, len(values))
+	for i, value := range values {
+		r, err := ParseRecipient(value)
+		if err != nil {
+			return nil, err
+		}
+		recipients[i] = r
+	}
+
+	return recipients, nil
+}
+
+// RecipientStrings returns the values of recipients, as expected by AliasParameters.Recipients.
+func RecipientStrings(recipients []Recipient) []string {
+	values := make([]string, len(recipients))
+	for i, r := range recipients {
+		values[i] = r.Value
+	}
+
+	return values
+}
+
+func (r Recipient) String() string {
+	return r.Value
+}
+
+func (r Recipient) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Value)
+}
+
+func (r *Recipient) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	*r = Recipient{Kind: recipientKind(s), Value: s}
+
+	return nil
+}
+
+// ParsedRecipients returns the recipients of the alias with their kind.
+func (a Alias) ParsedRecipients() []Recipient {
+	recipients := make([]Recipient, len(a.Recipients))
+	for i, value := range a.Recipients {
+		recipients[i] = Recipient{Kind: recipientKind(value), Value: value}
+	}
+
+	return recipients
+}
+
+func recipientKind(s string) RecipientKind {
+	if strings.Contains(s, "://") {
+		u, err := url.Parse(s)
+		if err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+			return RecipientWebhook
+		}
+
+		return RecipientUnknown
+	}
+
+	if strings.Contains(s, "@") {
+		addr, err := mail.ParseAddress(s)
+		if err == nil && addr.Name == "" && addr.Address == s {
+			return RecipientEmail
+		}
+
+		return RecipientUnknown
+	}
+
+	if net.ParseIP(s) != nil {
+		return RecipientIP
+	}
+
+	if isFQDN(s) {
+		return RecipientFQDN
+	}
+
+	return RecipientUnknown
+}
+
+func isFQDN(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if len(s) > 253 {
+		return false
+	}
+
+	labels := strings.Split(s, ".")
+	if len(labels) < 2 {
+		return false
+	}
+
+	for _, label := range labels {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, r := range label {
+			if r != '-' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,107 @@
+package forwardemail
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseRecipient(t *testing.T) {
+	tests := []struct {
+		recipient string
+		want      RecipientKind
+	}{
+		{recipient: "tony@stark.com", want: RecipientEmail},
+		{recipient: "https://example.com/webhook", want: RecipientWebhook},
+		{recipient: "http://example.com:8080/hook?x=1", want: RecipientWebhook},
+		{recipient: "mx.example.com", want: RecipientFQDN},
+		{recipient: "mx.example.com.", want: RecipientFQDN},
+		{recipient: "bücher.de", want: RecipientFQDN},
+		{recipient: "192.0.2.1", want: RecipientIP},
+		{recipient: "2001:db8::1", want: RecipientIP},
+		{recipient: "", want: RecipientUnknown},
+		{recipient: "tony", want: RecipientUnknown},
+		{recipient: "tony@", want: RecipientUnknown},
+		{recipient: "Tony <tony@stark.com>", want: RecipientUnknown},
+		{recipient: "ftp://example.com", want: RecipientUnknown},
+		{recipient: "https://", want: RecipientUnknown},
+		{recipient: "-bad.example.com", want: RecipientUnknown},
+		{recipient: "bad..example.com", want: RecipientUnknown},
+		{recipient: "under_score.example.com", want: RecipientUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.recipient, func(t *testing.T) {
+			got, err := ParseRecipient(tt.recipient)
+			if (err != nil) != (tt.want == RecipientUnknown) {
+				t.Errorf("unexpected error %v", err)
+			}
+
+			if diff := cmp.Diff(Recipient{Kind: tt.want, Value: tt.recipient}, got); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestRecipient_JSON(t *testing.T) {
+	var got struct {
+		Recipients []Recipient `json:"recipients"`
+	}
+	data := []byte(`{"recipients":["tony@stark.com","https://example.com/hook","mx.example.com","192.0.2.1","tony"]}`)
+
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Recipient{
+		{Kind: RecipientEmail, Value: "tony@stark.com"},
+		{Kind: RecipientWebhook, Value: "https://example.com/hook"},
+		{Kind: RecipientFQDN, Value: "mx.example.com"},
+		{Kind: RecipientIP, Value: "192.0.2.1"},
+		{Kind: RecipientUnknown, Value: "tony"},
+	}
+	if diff := cmp.Diff(want, got.Recipients); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+
+	encoded, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(data), string(encoded)); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+}
+
+func TestAlias_ParsedRecipients(t *testing.T) {
+	alias := Alias{Recipients: []string{"tony@stark.com", "https://example.com/hook"}}
+
+	got := alias.ParsedRecipients()
+
+	want := []Recipient{
+		{Kind: RecipientEmail, Value: "tony@stark.com"},
+		{Kind: RecipientWebhook, Value: "https://example.com/hook"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+	if diff := cmp.Diff(alias.Recipients, RecipientStrings(got)); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+}
+
+func TestParseRecipients(t *testing.T) {
+	if _, err := ParseRecipients([]string{"tony@stark.com", "tony"}); err == nil {
+		t.Error("expected an error for an invalid recipient")
+	}
+
+	got, err := ParseRecipients([]string{"tony@stark.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]Recipient{{Kind: RecipientEmail, Value: "tony@stark.com"}}, got); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+}
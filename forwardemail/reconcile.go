@@ -0,0 +1,219 @@
+package forwardemail
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// AliasSpec is the desired state of an alias for ReconcileAliases.
+type AliasSpec struct {
+	Name       string
+	Recipients []string
+	Labels     []string
+	// Description is left alone when empty, so specs without one keep the descriptions set by
+	// other means; clear it with UpdateAlias and AliasParameters.Clear instead.
+	Description string
+	// IsEnabled and HasRecipientVerification are left alone when nil.
+	IsEnabled                *bool
+	HasRecipientVerification *bool
+}
+
+// ReconcileOptions controls ReconcileAliases.
+type ReconcileOptions struct {
+	BulkOptions
+
+	// Prune deletes the aliases of the domain that are not in the desired list.
+	Prune bool
+	// IgnoreLabels lists labels marking aliases managed by other means: existing aliases
+	// carrying one of them are never updated nor deleted.
+	IgnoreLabels []string
+	// DryRun only computes the changes, without applying them.
+	DryRun bool
+}
+
+// AliasChange is a create, update or delete computed by ReconcileAliases. Fields lists the
+// fields that differ for updates, and is empty for creates and deletes.
+type AliasChange struct {
+	Name   string
+	Fields []string
+}
+
+// ReconcileReport is the outcome of ReconcileAliases. Results holds the outcome of every
+// change applied, and is empty for dry runs.
+type ReconcileReport struct {
+	Creates   []AliasChange
+	Updates   []AliasChange
+	Deletes   []AliasChange
+	Unchanged []string
+	// Ignored are the aliases left alone because of ReconcileOptions.IgnoreLabels.
+	Ignored []string
+	Results BulkResults
+}
+
+// HasChanges reports whether anything had to be created, updated or deleted.
+func (r *ReconcileReport) HasChanges() bool {
+	return len(r.Creates)+len(r.Updates)+len(r.Deletes) > 0
+}
+
+// ReconcileAliases makes the aliases of a domain match desired: missing aliases are created,
+// differing ones updated and, with options.Prune, the others deleted. Names are compared once
+// normalized with NormalizeAliasName and only the first spec of a name is used; recipients,
+// normalized with NormalizeRecipient, and labels are compared regardless of their order.
+func (c *Client) ReconcileAliases(domain string, desired []AliasSpec, options ReconcileOptions, opts ...RequestOption) (*ReconcileReport, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.ReconcileAliasesContext(ctx, domain, desired, options)
+}
+
+func (c *Client) ReconcileAliasesContext(ctx context.Context, domain string, desired []AliasSpec, options ReconcileOptions) (*ReconcileReport, error) {
+	current, err := c.GetAllAliasesContext(ctx, domain, ListAliasParameters{})
+	if err != nil {
+		return nil, err
+	}
+
+	report, creates, updates, deletes := diffAliases(current, desired, options)
+	if options.DryRun {
+		return report, nil
+	}
+
+	report.Results = append(report.Results, c.BulkCreateAliasesContext(ctx, domain, creates, options.BulkOptions)...)
+	report.Results = append(report.Results, c.BulkUpdateAliasesContext(ctx, domain, updates, options.BulkOptions)...)
+	report.Results = append(report.Results, c.BulkDeleteAliasesContext(ctx, domain, deletes, options.BulkOptions)...)
+
+	return report, nil
+}
+
+func diffAliases(current []Alias, desired []AliasSpec, options ReconcileOptions) (report *ReconcileReport, creates, updates []BulkAlias, deletes []string) {
+	report = &ReconcileReport{}
+
+	existing := map[string]Alias{}
+	for _, alias := range current {
+		existing[NormalizeAliasName(alias.Name)] = alias
+	}
+
+	wanted := map[string]bool{}
+	for _, spec := range desired {
+		key := NormalizeAliasName(spec.Name)
+		if wanted[key] {
+			continue
+		}
+		wanted[key] = true
+
+		alias, ok := existing[key]
+		switch {
+		case !ok:
+			report.Creates = append(report.Creates, AliasChange{Name: spec.Name})
+			creates = append(creates, BulkAlias{Name: spec.Name, Parameters: spec.parameters()})
+		case hasAnyLabel(alias, options.IgnoreLabels):
+			report.Ignored = append(report.Ignored, alias.Name)
+		default:
+			fields := spec.diff(alias)
+			if len(fields) == 0 {
+				report.Unchanged = append(report.Unchanged, alias.Name)
+				continue
+			}
+			report.Updates = append(report.Updates, AliasChange{Name: alias.Name, Fields: fields})
+			updates = append(updates, BulkAlias{Name: alias.Name, Parameters: spec.parameters()})
+		}
+	}
+
+	if options.Prune {
+		for _, alias := range current {
+			if wanted[NormalizeAliasName(alias.Name)] {
+				continue
+			}
+			if hasAnyLabel(alias, options.IgnoreLabels) {
+				report.Ignored = append(report.Ignored, alias.Name)
+				continue
+			}
+			report.Deletes = append(report.Deletes, AliasChange{Name: alias.Name})
+			deletes = append(deletes, alias.Name)
+		}
+	}
+
+	return report, creates, updates, deletes
+}
+
+func (s AliasSpec) parameters() AliasParameters {
+	recipients, labels := s.Recipients, s.Labels
+	if recipients == nil {
+		recipients = []string{}
+	}
+	if labels == nil {
+		labels = []string{}
+	}
+
+	return AliasParameters{
+		Recipients:               &recipients,
+		Labels:                   &labels,
+		Description:              s.Description,
+		IsEnabled:                s.IsEnabled,
+		HasRecipientVerification: s.HasRecipientVerification,
+	}
+}
+
+// diff returns the names of the fields of alias that differ from the spec.
+func (s AliasSpec) diff(alias Alias) []string {
+	var fields []string
+	if !sameSet(s.Recipients, alias.Recipients, NormalizeRecipient) {
+		fields = append(fields, "recipients")
+	}
+	if !sameSet(s.Labels, alias.Labels, strings.ToLower) {
+		fields = append(fields, "labels")
+	}
+	if s.Description != "" && s.Description != alias.Description {
+		fields = append(fields, "description")
+	}
+	if s.IsEnabled != nil && *s.IsEnabled != alias.IsEnabled {
+		fields = append(fields, "is_enabled")
+	}
+	if s.HasRecipientVerification != nil && *s.HasRecipientVerification != alias.HasRecipientVerification {
+		fields = append(fields, "has_recipient_verification")
+	}
+
+	return fields
+}
+
+// sameSet reports whether a and b hold the same values once normalized with key.
+func sameSet(a, b []string, key func(string) string) bool {
+	normalize := func(values []string) []string {
+		set := map[string]bool{}
+		for _, v := range values {
+			set[key(v)] = true
+		}
+
+		keys := make([]string, 0, len(set))
+		for k := range set {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		return keys
+	}
+
+	x, y := normalize(a), normalize(b)
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hasAnyLabel(alias Alias, labels []string) bool {
+	for _, label := range labels {
+		for _, l := range alias.Labels {
+			if strings.EqualFold(l, label) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
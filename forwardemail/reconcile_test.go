@@ -0,0 +1,123 @@
+package forwardemail
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_diffAliases(t *testing.T) {
+	current := []Alias{
+		{Name: "tony", Recipients: []string{"tony@stark.com", "ironman@stark.com", "tony@xn--bcher-kva.de"}, IsEnabled: true},
+		{Name: "pepper", Recipients: []string{"pepper@stark.com"}, Labels: []string{"ops"}, IsEnabled: true},
+		{Name: "happy", Recipients: []string{"happy@stark.com"}, IsEnabled: true},
+		{Name: "jarvis", Recipients: []string{"https://stark.com/hook"}, Labels: []string{"Manual"}},
+	}
+	desired := []AliasSpec{
+		{Name: "Tony", Recipients: []string{"ironman@stark.com", "TONY@stark.com", "tony@Bücher.de"}},
+		{Name: "pepper", Recipients: []string{"pepper@stark.com"}, Labels: []string{"ops", "exec"}, IsEnabled: pointBool(false)},
+		{Name: "jarvis", Recipients: []string{"https://STARK.com/Hook"}},
+		{Name: "rhodey", Recipients: []string{"rhodey@stark.com"}},
+		{Name: "rhodey", Recipients: []string{"duplicate@stark.com"}},
+	}
+
+	tests := []struct {
+		name    string
+		options ReconcileOptions
+		want    *ReconcileReport
+	}{
+		{
+			name: "without pruning",
+			want: &ReconcileReport{
+				Creates:   []AliasChange{{Name: "rhodey"}},
+				Updates:   []AliasChange{{Name: "pepper", Fields: []string{"labels", "is_enabled"}}, {Name: "jarvis", Fields: []string{"recipients", "labels"}}},
+				Unchanged: []string{"tony"},
+			},
+		},
+		{
+			name:    "pruning with ignored labels",
+			options: ReconcileOptions{Prune: true, IgnoreLabels: []string{"manual"}},
+			want: &ReconcileReport{
+				Creates:   []AliasChange{{Name: "rhodey"}},
+				Updates:   []AliasChange{{Name: "pepper", Fields: []string{"labels", "is_enabled"}}},
+				Deletes:   []AliasChange{{Name: "happy"}},
+				Unchanged: []string{"tony"},
+				Ignored:   []string{"jarvis"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, _, _ := diffAliases(current, desired, tt.options)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_ReconcileAliases(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/v1/domains/stark.com/aliases":
+			fmt.Fprint(w, `[{"name": "tony", "recipients": ["tony@stark.com"]}, {"name": "happy", "recipients": ["happy@stark.com"]}]`)
+		case r.Method == "GET":
+			fmt.Fprint(w, `{"name": "tony", "recipients": ["tony@stark.com"]}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	desired := []AliasSpec{
+		{Name: "tony", Recipients: []string{"ironman@stark.com"}},
+		{Name: "pepper", Recipients: []string{"pepper@stark.com"}},
+	}
+
+	report, err := c.ReconcileAliases("stark.com", desired, ReconcileOptions{Prune: true, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.HasChanges() || len(report.Results) != 0 {
+		t.Fatalf("unexpected dry run report %+v", report)
+	}
+	if diff := cmp.Diff([]string{"GET /v1/domains/stark.com/aliases"}, calls); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+
+	calls = nil
+	report, err = c.ReconcileAliases("stark.com", desired, ReconcileOptions{Prune: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := report.Results.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(calls)
+	want := []string{
+		"DELETE /v1/domains/stark.com/aliases/happy",
+		"GET /v1/domains/stark.com/aliases",
+		"POST /v1/domains/stark.com/aliases",
+		"PUT /v1/domains/stark.com/aliases/tony",
+	}
+	if diff := cmp.Diff(want, calls); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+}
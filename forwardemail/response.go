@@ -0,0 +1,80 @@
+package forwardemail
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestIDHeader is the response header identifying a request to Forward Email's support.
+const RequestIDHeader = "X-Request-Id"
+
+// Response is the metadata of an API response, kept alongside the decoded results.
+type Response struct {
+	StatusCode int
+	// RequestID identifies the request to Forward Email's support.
+	RequestID string
+	// Date is the server time of the response, zero when the Date header is missing.
+	Date time.Time
+	// RateLimit and Pagination are nil when the response doesn't report them.
+	RateLimit  *RateLimit
+	Pagination *Pagination
+	Header     http.Header
+}
+
+func newResponse(res *http.Response) *Response {
+	r := &Response{
+		StatusCode: res.StatusCode,
+		RequestID:  res.Header.Get(RequestIDHeader),
+		Header:     res.Header,
+	}
+	if date, err := http.ParseTime(res.Header.Get("Date")); err == nil {
+		r.Date = date
+	}
+	if rl, ok := parseRateLimit(res.Header); ok {
+		r.RateLimit = &rl
+	}
+	if res.Header.Get("X-Page-Count") != "" || res.Header.Get("X-Item-Count") != "" {
+		r.Pagination = parsePagination(res.Header)
+	}
+
+	return r
+}
+
+// LastResponse returns the metadata of the latest response received by the client, and false
+// when none has been received yet. With concurrent calls it may belong to any of them: use
+// ContextWithResponse or CaptureResponse to get the response of a given call.
+func (c *Client) LastResponse() (*Response, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.lastResponse, c.lastResponse != nil
+}
+
+type responseKey struct{}
+
+// ContextWithResponse makes the calls made with ctx store the metadata of their responses in dst.
+// For calls made of several requests, such as UpdateAlias or GetAllAliases, dst holds the
+// last one. Responses served from the cache are not recorded.
+func ContextWithResponse(ctx context.Context, dst *Response) context.Context {
+	return context.WithValue(ctx, responseKey{}, dst)
+}
+
+// CaptureResponse is ContextWithResponse for methods that don't take a context.
+func CaptureResponse(dst *Response) RequestOption {
+	return func(o *requestOptions) {
+		o.response = dst
+	}
+}
+
+func (c *Client) recordResponse(req *http.Request, res *http.Response) {
+	r := newResponse(res)
+
+	c.mu.Lock()
+	c.lastResponse = r
+	c.mu.Unlock()
+
+	if dst, ok := req.Context().Value(responseKey{}).(*Response); ok && dst != nil {
+		*dst = *r
+	}
+}
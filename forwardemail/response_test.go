@@ -0,0 +1,102 @@
+package forwardemail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_newResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   *Response
+	}{
+		{
+			name:   "no metadata",
+			header: http.Header{},
+			want:   &Response{StatusCode: http.StatusOK},
+		},
+		{
+			name: "all metadata",
+			header: http.Header{
+				"X-Request-Id":          {"b3c1"},
+				"Date":                  {"Wed, 14 Oct 2026 07:00:00 GMT"},
+				"X-Ratelimit-Limit":     {"1000"},
+				"X-Ratelimit-Remaining": {"999"},
+				"X-Ratelimit-Reset":     {"1791961200"},
+				"X-Page-Count":          {"3"},
+				"X-Page-Current":        {"1"},
+				"X-Item-Count":          {"25"},
+			},
+			want: &Response{
+				StatusCode: http.StatusOK,
+				RequestID:  "b3c1",
+				Date:       time.Date(2026, 10, 14, 7, 0, 0, 0, time.UTC),
+				RateLimit:  &RateLimit{Limit: 1000, Remaining: 999, Reset: time.Unix(1791961200, 0)},
+				Pagination: &Pagination{PageCount: 3, CurrentPage: 1, ItemCount: 25},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newResponse(&http.Response{StatusCode: http.StatusOK, Header: tt.header})
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(Response{}, "Header")); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_CaptureResponse(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", r.URL.Path)
+		if r.URL.Path == "/v1/domains/wayne.com" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"statusCode": 404, "message": "Not Found"}`)
+			return
+		}
+		fmt.Fprint(w, `{"name": "stark.com"}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	if _, ok := c.LastResponse(); ok {
+		t.Fatal("expected no response before the first request")
+	}
+
+	var res Response
+	if _, err := c.GetDomain("stark.com", CaptureResponse(&res)); err != nil {
+		t.Fatal(err)
+	}
+	if res.RequestID != "/v1/domains/stark.com" || res.StatusCode != http.StatusOK {
+		t.Errorf("unexpected response %+v", res)
+	}
+
+	var ctxRes Response
+	_, err := c.GetDomainContext(ContextWithResponse(context.Background(), &ctxRes), "wayne.com")
+	if ctxRes.RequestID != "/v1/domains/wayne.com" || ctxRes.StatusCode != http.StatusNotFound {
+		t.Errorf("unexpected response %+v", ctxRes)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RequestID != "/v1/domains/wayne.com" {
+		t.Errorf("expected the request ID on the error, got %v", err)
+	}
+
+	last, ok := c.LastResponse()
+	if !ok || last.RequestID != "/v1/domains/wayne.com" {
+		t.Errorf("unexpected last response %+v", last)
+	}
+}
@@ -0,0 +1,80 @@
+package forwardemail
+
+import (
+	"context"
+)
+
+// DomainRestrictions are the domain-wide sender filters and the alias names reserved to
+// admins, which members of a team domain can't create.
+type DomainRestrictions struct {
+	Allowlist            []string
+	Denylist             []string
+	RestrictedAliasNames []string
+}
+
+// DomainRestrictionsParameters updates the restrictions of a domain. Nil fields are left
+// unchanged and an empty slice clears the list.
+type DomainRestrictionsParameters struct {
+	Allowlist            *[]string `json:"allowlist,omitempty"`
+	Denylist             *[]string `json:"denylist,omitempty"`
+	RestrictedAliasNames *[]string `json:"restricted_alias_names,omitempty"`
+}
+
+// GetDomainRestrictions returns the allowlist, denylist and restricted alias names of a domain.
+func (c *Client) GetDomainRestrictions(domain string, opts ...RequestOption) (*DomainRestrictions, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetDomainRestrictionsContext(ctx, domain)
+}
+
+func (c *Client) GetDomainRestrictionsContext(ctx context.Context, domain string) (*DomainRestrictions, error) {
+	item, err := c.GetDomainContext(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return item.restrictions(), nil
+}
+
+// UpdateDomainRestrictions replaces the lists set in parameters and returns the resulting restrictions.
+func (c *Client) UpdateDomainRestrictions(domain string, parameters DomainRestrictionsParameters, opts ...RequestOption) (*DomainRestrictions, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.UpdateDomainRestrictionsContext(ctx, domain, parameters)
+}
+
+func (c *Client) UpdateDomainRestrictionsContext(ctx context.Context, domain string, parameters DomainRestrictionsParameters) (*DomainRestrictions, error) {
+	req, err := c.newRequest(ctx, "PUT", pathf("/v1/domains/%s", domain))
+	if err != nil {
+		return nil, err
+	}
+
+	err = setJSONBody(req, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var item Domain
+
+	err = c.unmarshal(req, res, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	return item.restrictions(), nil
+}
+
+func (d *Domain) restrictions() *DomainRestrictions {
+	return &DomainRestrictions{
+		Allowlist:            d.Allowlist,
+		Denylist:             d.Denylist,
+		RestrictedAliasNames: d.RestrictedAliasNames,
+	}
+}
@@ -0,0 +1,91 @@
+package forwardemail
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const restrictedDomain = `{
+	"name": "stark.com",
+	"allowlist": ["shield.gov"],
+	"denylist": ["hydra.org", "aim.com"],
+	"restricted_alias_names": ["admin", "security"]
+}`
+
+func TestClient_GetDomainRestrictions(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, restrictedDomain)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	got, err := c.GetDomainRestrictions("stark.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &DomainRestrictions{
+		Allowlist:            []string{"shield.gov"},
+		Denylist:             []string{"hydra.org", "aim.com"},
+		RestrictedAliasNames: []string{"admin", "security"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestClient_UpdateDomainRestrictions(t *testing.T) {
+	tests := []struct {
+		name   string
+		params DomainRestrictionsParameters
+		want   string
+	}{
+		{
+			name: "denylist only",
+			params: DomainRestrictionsParameters{
+				Denylist: pointSliceOfStrings([]string{"hydra.org", "aim.com"}),
+			},
+			want: `{"denylist":["hydra.org","aim.com"]}`,
+		},
+		{
+			name: "cleared allowlist",
+			params: DomainRestrictionsParameters{
+				Allowlist:            pointSliceOfStrings([]string{}),
+				RestrictedAliasNames: pointSliceOfStrings([]string{"admin"}),
+			},
+			want: `{"allowlist":[],"restricted_alias_names":["admin"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var method, body string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				method, body = r.Method, string(b)
+
+				fmt.Fprint(w, restrictedDomain)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			if _, err := c.UpdateDomainRestrictions("stark.com", tt.params); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff([]string{http.MethodPut, tt.want}, []string{method, body}); diff != "" {
+				t.Fatalf("request bodies are not the same %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package forwardemail
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures automatic retries of failed requests.
+// A nil policy, or one with MaxAttempts of 1 or less, disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry; it doubles on every attempt.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between attempts, including the one asked for by a
+	// Retry-After header. Zero leaves Retry-After uncapped.
+	MaxBackoff time.Duration
+	// Jitter randomizes every delay between zero and the computed backoff.
+	Jitter bool
+}
+
+// DefaultRetryPolicy is a reasonable policy for long-running jobs.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+	Jitter:      true,
+}
+
+func (p *RetryPolicy) enabled() bool {
+	return p != nil && p.MaxAttempts > 1
+}
+
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff << (attempt - 1)
+	if d <= 0 || (p.MaxBackoff > 0 && d > p.MaxBackoff) {
+		d = p.MaxBackoff
+	}
+
+	if p.Jitter && d > 0 {
+		d = time.Duration(rand.Int63n(int64(d) + 1))
+	}
+
+	return d
+}
+
+// canRetry reports whether the request may be sent again after the given outcome.
+// Rate-limited requests were never processed, so they are retried whatever the method;
+// other failures are only retried for idempotent methods.
+func canRetry(req *http.Request, res *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return isIdempotent(req.Method) && req.Context().Err() == nil
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	}
+
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+type attemptKey struct{}
+
+// Attempt returns the attempt number of the request carrying ctx, starting at 1. It lets
+// request hooks and custom transports tell retries apart from first attempts.
+func Attempt(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptKey{}).(int); ok {
+		return attempt
+	}
+
+	return 1
+}
+
+func (c *Client) doWithRetry(req *http.Request, policy *RetryPolicy) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 {
+			attemptReq = req.WithContext(context.WithValue(req.Context(), attemptKey{}, attempt))
+		}
+
+		res, err := c.send(attemptReq)
+		if attempt >= policy.MaxAttempts || !canRetry(req, res, err) {
+			return res, err
+		}
+
+		wait := policy.backoff(attempt)
+		if res != nil {
+			if retryAfter := parseRetryAfter(res.Header.Get("Retry-After"), c.clock.Now()); retryAfter > 0 {
+				wait = retryAfter
+				if policy.MaxBackoff > 0 && wait > policy.MaxBackoff {
+					wait = policy.MaxBackoff
+				}
+			}
+
+			drainAndClose(res.Body)
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		c.logRetry(req, attempt, wait)
+
+		if err := c.clock.Sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package forwardemail
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_Retry(t *testing.T) {
+	type response struct {
+		codes []int
+	}
+
+	policy := &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+		Jitter:      true,
+	}
+
+	tests := []struct {
+		name       string
+		policy     *RetryPolicy
+		method     string
+		body       string
+		res        response
+		wantCalls  int
+		wantStatus int
+	}{
+		{
+			name:       "disabled",
+			method:     "GET",
+			res:        response{codes: []int{503, 200}},
+			wantCalls:  1,
+			wantStatus: 503,
+		},
+		{
+			name:       "retries get until success",
+			policy:     policy,
+			method:     "GET",
+			res:        response{codes: []int{503, 502, 200}},
+			wantCalls:  3,
+			wantStatus: 200,
+		},
+		{
+			name:       "gives up after max attempts",
+			policy:     policy,
+			method:     "GET",
+			res:        response{codes: []int{503, 503, 503, 200}},
+			wantCalls:  3,
+			wantStatus: 503,
+		},
+		{
+			name:       "does not retry non idempotent requests on server errors",
+			policy:     policy,
+			method:     "POST",
+			body:       "name=tony",
+			res:        response{codes: []int{503, 200}},
+			wantCalls:  1,
+			wantStatus: 503,
+		},
+		{
+			name:       "retries rate limited non idempotent requests",
+			policy:     policy,
+			method:     "POST",
+			body:       "name=tony",
+			res:        response{codes: []int{429, 200}},
+			wantCalls:  2,
+			wantStatus: 200,
+		},
+		{
+			name:       "does not retry client errors",
+			policy:     policy,
+			method:     "GET",
+			res:        response{codes: []int{404, 200}},
+			wantCalls:  1,
+			wantStatus: 404,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			var bodies []string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b := make([]byte, 64)
+				n, _ := r.Body.Read(b)
+				bodies = append(bodies, string(b[:n]))
+
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(tt.res.codes[calls])
+				calls++
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl:      svr.URL,
+				RetryPolicy: tt.policy,
+			})
+
+			req, err := c.NewRequest(tt.method, "/v1/domains", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := c.DoRaw(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+
+			if diff := cmp.Diff(tt.wantStatus, res.StatusCode); diff != "" {
+				t.Fatalf("status codes are not the same %s", diff)
+			}
+			if diff := cmp.Diff(tt.wantCalls, calls); diff != "" {
+				t.Fatalf("calls are not the same %s", diff)
+			}
+			for _, b := range bodies {
+				if diff := cmp.Diff(tt.body, b); diff != "" {
+					t.Fatalf("request bodies are not the same %s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_Retry_RetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxBackoff time.Duration
+		want       []time.Duration
+	}{
+		{
+			name:       "capped",
+			maxBackoff: 30 * time.Second,
+			want:       []time.Duration{30 * time.Second},
+		},
+		{
+			name: "uncapped",
+			want: []time.Duration{10 * time.Minute},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls == 1 {
+					w.Header().Set("Retry-After", "600")
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+
+				fmt.Fprint(w, `{}`)
+			}))
+			defer svr.Close()
+
+			clock := &sleepRecorder{now: time.Unix(1696968766, 0)}
+			c := NewClient(ClientOptions{
+				ApiUrl:      svr.URL,
+				RetryPolicy: &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Second, MaxBackoff: tt.maxBackoff},
+			}, WithClock(clock))
+
+			if _, err := c.GetAccount(); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, clock.slept); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := &RetryPolicy{
+		MinBackoff: time.Second,
+		MaxBackoff: 5 * time.Second,
+	}
+
+	var got []time.Duration
+	for attempt := 1; attempt <= 5; attempt++ {
+		got = append(got, policy.backoff(attempt))
+	}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestAttempt(t *testing.T) {
+	calls := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer svr.Close()
+
+	var got []int
+	c := NewClient(ClientOptions{
+		ApiUrl:      svr.URL,
+		RetryPolicy: &RetryPolicy{MaxAttempts: 3},
+	}, WithRequestHook(func(r *http.Request) {
+		got = append(got, Attempt(r.Context()))
+	}))
+
+	req, err := c.NewRequest("GET", "/v1/account", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := c.DoRaw(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if diff := cmp.Diff([]int{1, 2, 3}, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
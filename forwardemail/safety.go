@@ -0,0 +1,59 @@
+package forwardemail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SafetyError is returned by SafeDeleteDomain when a guard prevents the deletion. Nothing was
+// deleted.
+type SafetyError struct {
+	Domain string
+	Reason string
+}
+
+func (e *SafetyError) Error() string {
+	return fmt.Sprintf("refusing to delete domain %s: %s", e.Domain, e.Reason)
+}
+
+// DeleteGuards configures the checks made by SafeDeleteDomain.
+type DeleteGuards struct {
+	// Confirm, when set, must be the domain name, compared case-insensitively, guarding
+	// against a typo or a wrong variable deleting another domain.
+	Confirm string
+	// MaxAliases is the number of aliases above which the deletion is refused. There is no
+	// default: the zero value only allows deleting domains without aliases, and a negative
+	// value disables the check.
+	MaxAliases int
+	// Force deletes the domain whatever its number of aliases. Confirm is still checked.
+	Force bool
+}
+
+// SafeDeleteDomain deletes a domain like DeleteDomain, after making sure the guards allow it:
+// the name must match Confirm, if set, and the domain must not have more aliases than
+// MaxAliases, unless Force is set. A refusal is reported as a *SafetyError.
+func (c *Client) SafeDeleteDomain(name string, guards DeleteGuards, opts ...RequestOption) error {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.SafeDeleteDomainContext(ctx, name, guards)
+}
+
+func (c *Client) SafeDeleteDomainContext(ctx context.Context, name string, guards DeleteGuards) error {
+	if guards.Confirm != "" && !strings.EqualFold(guards.Confirm, name) {
+		return &SafetyError{Domain: name, Reason: fmt.Sprintf("confirmation %q does not match", guards.Confirm)}
+	}
+
+	if !guards.Force && guards.MaxAliases >= 0 {
+		count, err := c.countAliases(ctx, name)
+		if err != nil {
+			return err
+		}
+		if count > guards.MaxAliases {
+			return &SafetyError{Domain: name, Reason: fmt.Sprintf("it has %d aliases, more than %d", count, guards.MaxAliases)}
+		}
+	}
+
+	return c.DeleteDomainContext(ctx, name)
+}
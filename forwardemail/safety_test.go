@@ -0,0 +1,66 @@
+package forwardemail
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_SafeDeleteDomain(t *testing.T) {
+	tests := []struct {
+		name       string
+		domain     string
+		itemCount  string
+		guards     DeleteGuards
+		wantSafety bool
+	}{
+		{name: "few aliases", domain: "stark.com", itemCount: "3", guards: DeleteGuards{MaxAliases: 10}},
+		{name: "too many aliases", domain: "stark.com", itemCount: "11", guards: DeleteGuards{MaxAliases: 10}, wantSafety: true},
+		{name: "zero value", domain: "stark.com", itemCount: "1", wantSafety: true},
+		{name: "check disabled", domain: "stark.com", itemCount: "500", guards: DeleteGuards{MaxAliases: -1}},
+		{name: "forced", domain: "stark.com", itemCount: "500", guards: DeleteGuards{Force: true}},
+		{name: "confirmed", domain: "stark.com", itemCount: "3", guards: DeleteGuards{Confirm: "Stark.com", MaxAliases: 10}},
+		{name: "confirmation mismatch", domain: "stark.com", itemCount: "3", guards: DeleteGuards{Confirm: "starks.com", Force: true}, wantSafety: true},
+		{name: "missing item count", domain: "stark.com", guards: DeleteGuards{MaxAliases: 1}, wantSafety: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "DELETE" && r.URL.Path == "/v1/domains/stark.com":
+					deleted = true
+					fmt.Fprint(w, `{}`)
+				case r.URL.Path == "/v1/domains/stark.com/aliases":
+					w.Header().Set("X-Item-Count", tt.itemCount)
+					w.Header().Set("X-Page-Count", "1")
+					w.Header().Set("X-Page-Current", "1")
+					fmt.Fprint(w, `[{"name": "tony"}, {"name": "pepper"}]`)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			err := c.SafeDeleteDomain(tt.domain, tt.guards)
+
+			var safetyErr *SafetyError
+			if errors.As(err, &safetyErr) != tt.wantSafety {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !tt.wantSafety && err != nil {
+				t.Fatal(err)
+			}
+			if deleted == tt.wantSafety {
+				t.Errorf("expected deleted to be %v", !tt.wantSafety)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package forwardemail
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrFeatureUnavailable is returned by the methods of an optional part of the API when the
+// instance lacks it, as found by DetectFeatures or told by a 404 from its endpoints.
+var ErrFeatureUnavailable = errors.New("forwardemail: feature unavailable on this instance")
+
+// WithRootCAs trusts the certificates in pool, e.g. the private CA of a self-hosted instance,
+// instead of the system roots. Like WithTransportOptions, it tunes a clone of the transport
+// in use, which must be an *http.Transport, and must come after WithHTTPClient.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *Client) {
+		c.tuneTransport("WithRootCAs", func(transport *http.Transport) {
+			if transport.TLSClientConfig == nil {
+				transport.TLSClientConfig = &tls.Config{}
+			}
+			transport.TLSClientConfig.RootCAs = pool
+		})
+	}
+}
+
+// WithBasicAuth authenticates with a username and password instead of the API key, for
+// self-hosted instances sitting behind a proxy that expects them.
+func WithBasicAuth(username, password string) Option {
+	return func(c *Client) {
+		c.auth = BasicAuth{Username: username, Password: password}
+	}
+}
+
+// Ping checks that the API can be reached and accepts the credentials of the client.
+func (c *Client) Ping(opts ...RequestOption) error {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.PingContext(ctx)
+}
+
+func (c *Client) PingContext(ctx context.Context) error {
+	_, err := c.GetAccountContext(ctx)
+
+	return err
+}
+
+// Features lists the optional parts of the API found on an instance. Self-hosted instances
+// may run releases older than forwardemail.net and lack some of them. Only outbound SMTP is
+// detected for now.
+type Features struct {
+	// OutboundSMTP is true when the emails and sending limit endpoints exist.
+	OutboundSMTP bool
+}
+
+// DetectFeatures probes the instance for outbound SMTP, the only optional part of the API
+// it knows about, with a GetEmailLimit call; the API exposes no version to compare. The
+// client keeps the result: once an instance is found to lack outbound SMTP, the email
+// methods return ErrFeatureUnavailable without sending anything. Without detection, they
+// only return it when the instance answers 404.
+func (c *Client) DetectFeatures(opts ...RequestOption) (*Features, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.DetectFeaturesContext(ctx)
+}
+
+func (c *Client) DetectFeaturesContext(ctx context.Context) (*Features, error) {
+	var features Features
+
+	_, err := c.getEmailLimit(ctx)
+	switch {
+	case err == nil:
+		features.OutboundSMTP = true
+	case !IsNotFound(err):
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.features = &features
+	c.mu.Unlock()
+
+	return &features, nil
+}
+
+// requireOutboundSMTP returns ErrFeatureUnavailable when DetectFeatures found the instance
+// lacks outbound SMTP.
+func (c *Client) requireOutboundSMTP() error {
+	c.mu.RLock()
+	features := c.features
+	c.mu.RUnlock()
+
+	if features != nil && !features.OutboundSMTP {
+		return fmt.Errorf("%w: outbound SMTP", ErrFeatureUnavailable)
+	}
+
+	return nil
+}
+
+// outboundSMTPError maps a 404 from the endpoints of outbound SMTP that exist whatever the
+// emails, which the instance then lacks, to ErrFeatureUnavailable. The *APIError is kept in
+// the chain.
+func outboundSMTPError(err error) error {
+	if IsNotFound(err) {
+		return fmt.Errorf("%w: outbound SMTP: %w", ErrFeatureUnavailable, err)
+	}
+
+	return err
+}
@@ -0,0 +1,141 @@
+package forwardemail
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithRootCAs(t *testing.T) {
+	svr := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	if err := NewClient(ClientOptions{ApiUrl: svr.URL}).Ping(); err == nil {
+		t.Fatal("expected the self-signed certificate to be rejected")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(svr.Certificate())
+
+	c := NewClient(ClientOptions{ApiUrl: svr.URL}, WithRootCAs(pool))
+	if err := c.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	if http.DefaultClient.Transport != nil {
+		t.Fatal("http.DefaultClient was modified")
+	}
+}
+
+func TestWithBasicAuth(t *testing.T) {
+	var got []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		got = []string{username, password}
+
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiKey: "the-api-key",
+		ApiUrl: svr.URL,
+	}, WithBasicAuth("admin", "hunter2"))
+
+	if err := c.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"admin", "hunter2"}, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestClient_DetectFeatures(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		want    *Features
+		wantErr bool
+	}{
+		{
+			name:   "outbound smtp available",
+			status: http.StatusOK,
+			want:   &Features{OutboundSMTP: true},
+		},
+		{
+			name:   "outbound smtp missing",
+			status: http.StatusNotFound,
+			want:   &Features{},
+		},
+		{
+			name:    "server error",
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, `{"count": 0, "limit": 300}`)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, err := c.DetectFeatures()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_OutboundSMTPUnavailable(t *testing.T) {
+	var calls []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Not Found"}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	// Without detection, the 404 of the endpoint tells the feature is missing.
+	_, err := c.CreateEmail(EmailParameters{From: "tony@stark.com", To: []string{"pepper@stark.com"}})
+	if !errors.Is(err, ErrFeatureUnavailable) || !IsNotFound(err) {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if _, err := c.DetectFeatures(); err != nil {
+		t.Fatal(err)
+	}
+
+	calls = nil
+	_, err = c.GetEmails()
+	if !errors.Is(err, ErrFeatureUnavailable) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := c.GetEmailLimit(); !errors.Is(err, ErrFeatureUnavailable) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("no request should reach the API, got %v", calls)
+	}
+}
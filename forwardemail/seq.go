@@ -0,0 +1,64 @@
+//go:build go1.23
+
+package forwardemail
+
+import (
+	"context"
+	"iter"
+)
+
+// AliasSeq yields every alias of a domain, fetching one page at a time as the loop
+// advances. A failed page yields its error once and ends the sequence:
+//
+//	for alias, err := range client.AliasSeq(ctx, "stark.com") {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *Client) AliasSeq(ctx context.Context, domain string) iter.Seq2[Alias, error] {
+	return c.AliasSeqFiltered(ctx, domain, ListAliasParameters{})
+}
+
+// AliasSeqFiltered is like AliasSeq for the aliases matching parameters, starting from parameters.Page.
+func (c *Client) AliasSeqFiltered(ctx context.Context, domain string, parameters ListAliasParameters) iter.Seq2[Alias, error] {
+	return seqPages(parameters.Page, func(page int) ([]Alias, *Pagination, error) {
+		parameters.Page = page
+		return c.GetAliasesPageContext(ctx, domain, parameters)
+	})
+}
+
+// DomainSeq yields every domain of the account, fetching one page at a time like AliasSeq.
+func (c *Client) DomainSeq(ctx context.Context) iter.Seq2[Domain, error] {
+	return seqPages(1, func(page int) ([]Domain, *Pagination, error) {
+		return c.GetDomainsPageContext(ctx, ListDomainParameters{ListOptions: ListOptions{Page: page}})
+	})
+}
+
+// seqPages is the lazy counterpart of collectPages.
+func seqPages[T any](first int, fetch func(page int) ([]T, *Pagination, error)) iter.Seq2[T, error] {
+	if first < 1 {
+		first = 1
+	}
+
+	return func(yield func(T, error) bool) {
+		for page := first; ; page++ {
+			items, pagination, err := fetch(page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(items) == 0 || !pagination.HasNextPage() {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+//go:build go1.23
+
+package forwardemail
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_AliasSeq(t *testing.T) {
+	var pages []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		w.Header().Set("X-Page-Count", "3")
+		w.Header().Set("X-Page-Current", page)
+		switch page {
+		case "1":
+			fmt.Fprint(w, `[{"name": "tony"}, {"name": "pepper"}]`)
+		case "2":
+			fmt.Fprint(w, `[{"name": "happy"}]`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	t.Run("stops early", func(t *testing.T) {
+		pages = nil
+
+		var got []string
+		for alias, err := range c.AliasSeq(context.Background(), "stark.com") {
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, alias.Name)
+			if len(got) == 2 {
+				break
+			}
+		}
+
+		if diff := cmp.Diff([]string{"tony", "pepper"}, got); diff != "" {
+			t.Fatalf("values are not the same %s", diff)
+		}
+		if diff := cmp.Diff([]string{"1"}, pages); diff != "" {
+			t.Fatalf("later pages should not be fetched %s", diff)
+		}
+	})
+
+	t.Run("yields errors", func(t *testing.T) {
+		var got []string
+		var errs int
+		for alias, err := range c.AliasSeq(context.Background(), "stark.com") {
+			if err != nil {
+				errs++
+				continue
+			}
+			got = append(got, alias.Name)
+		}
+
+		if diff := cmp.Diff([]string{"tony", "pepper", "happy"}, got); diff != "" {
+			t.Fatalf("values are not the same %s", diff)
+		}
+		if errs != 1 {
+			t.Fatalf("expected a single error, got %d", errs)
+		}
+	})
+}
+
+func TestClient_DomainSeq(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+
+		w.Header().Set("X-Page-Count", "2")
+		w.Header().Set("X-Page-Current", page)
+		fmt.Fprintf(w, `[{"name": "stark-%s.com"}]`, page)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	var got []string
+	for domain, err := range c.DomainSeq(context.Background()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, domain.Name)
+	}
+
+	if diff := cmp.Diff([]string{"stark-1.com", "stark-2.com"}, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
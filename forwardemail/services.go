@@ -0,0 +1,47 @@
+package forwardemail
+
+import "context"
+
+// AccountService is the account part of the API implemented by Client.
+type AccountService interface {
+	GetAccount(opts ...RequestOption) (*Account, error)
+	GetAccountContext(ctx context.Context) (*Account, error)
+	UpdateAccount(parameters AccountParameters, opts ...RequestOption) (*Account, error)
+	UpdateAccountContext(ctx context.Context, parameters AccountParameters) (*Account, error)
+}
+
+// DomainService is the domains part of the API implemented by Client.
+// Depend on it rather than on *Client to substitute a fake in tests.
+type DomainService interface {
+	GetDomains(opts ...RequestOption) ([]Domain, error)
+	GetDomainsContext(ctx context.Context) ([]Domain, error)
+	GetDomain(name string, opts ...RequestOption) (*Domain, error)
+	GetDomainContext(ctx context.Context, name string) (*Domain, error)
+	CreateDomain(name string, parameters DomainParameters, opts ...RequestOption) (*Domain, error)
+	CreateDomainContext(ctx context.Context, name string, parameters DomainParameters) (*Domain, error)
+	UpdateDomain(name string, parameters DomainParameters, opts ...RequestOption) (*Domain, error)
+	UpdateDomainContext(ctx context.Context, name string, parameters DomainParameters) (*Domain, error)
+	DeleteDomain(name string, opts ...RequestOption) error
+	DeleteDomainContext(ctx context.Context, name string) error
+}
+
+// AliasService is the aliases part of the API implemented by Client.
+// Depend on it rather than on *Client to substitute a fake in tests.
+type AliasService interface {
+	GetAliases(domain string, opts ...RequestOption) ([]Alias, error)
+	GetAliasesContext(ctx context.Context, domain string) ([]Alias, error)
+	GetAlias(domain string, alias string, opts ...RequestOption) (*Alias, error)
+	GetAliasContext(ctx context.Context, domain string, alias string) (*Alias, error)
+	CreateAlias(domain string, alias string, parameters AliasParameters, opts ...RequestOption) (*Alias, error)
+	CreateAliasContext(ctx context.Context, domain string, alias string, parameters AliasParameters) (*Alias, error)
+	UpdateAlias(domain string, alias string, parameters AliasParameters, opts ...RequestOption) (*Alias, error)
+	UpdateAliasContext(ctx context.Context, domain string, alias string, parameters AliasParameters) (*Alias, error)
+	DeleteAlias(domain string, alias string, opts ...RequestOption) error
+	DeleteAliasContext(ctx context.Context, domain string, alias string) error
+}
+
+var (
+	_ AccountService = (*Client)(nil)
+	_ DomainService  = (*Client)(nil)
+	_ AliasService   = (*Client)(nil)
+)
@@ -0,0 +1,113 @@
+package forwardemail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// SMTPConfiguration is the outbound SMTP setup of a domain, as shown in the "Outbound SMTP
+// Configuration" page of the dashboard. Outbound SMTP access itself is requested from the
+// dashboard, the API has no endpoint for it; once granted, Enabled is set.
+type SMTPConfiguration struct {
+	Domain    string
+	Enabled   bool
+	Suspended bool
+
+	// DKIM and ReturnPath are nil until the API has generated them for the domain.
+	DKIM       *DNSRecord
+	ReturnPath *DNSRecord
+	// DMARC is the suggested policy; any policy aligned with the DKIM and Return-Path records passes verification.
+	DMARC DNSRecord
+
+	Status SMTPStatus
+}
+
+// SMTPStatus tells which outbound SMTP records the API found.
+type SMTPStatus struct {
+	DKIM       bool
+	ReturnPath bool
+	DMARC      bool
+
+	// Message is the result of the last verification, either the confirmation or what is
+	// missing. It is only set by CheckSMTP.
+	Message string
+}
+
+// Verified reports whether every outbound SMTP record was found.
+func (s SMTPStatus) Verified() bool {
+	return s.DKIM && s.ReturnPath && s.DMARC
+}
+
+// SMTPConfiguration returns the outbound SMTP records of the domain and their status.
+func (d *Domain) SMTPConfiguration() SMTPConfiguration {
+	records := d.Records()
+
+	return SMTPConfiguration{
+		Domain:     d.Name,
+		Enabled:    d.HasSMTP,
+		Suspended:  d.IsSMTPSuspended,
+		DKIM:       records.DKIM,
+		ReturnPath: records.ReturnPath,
+		DMARC: DNSRecord{
+			Type:  "TXT",
+			Name:  fmt.Sprintf("_dmarc.%s", d.Name),
+			Value: "v=DMARC1; p=reject; pct=100;",
+			TTL:   defaultDNSRecordTTL,
+		},
+		Status: SMTPStatus{
+			DKIM:       d.HasDkimRecord,
+			ReturnPath: d.HasReturnPathRecord,
+			DMARC:      d.HasDmarcRecord,
+		},
+	}
+}
+
+// GetSMTPConfiguration returns the outbound SMTP configuration of a domain as last verified by the API.
+func (c *Client) GetSMTPConfiguration(name string, opts ...RequestOption) (*SMTPConfiguration, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetSMTPConfigurationContext(ctx, name)
+}
+
+func (c *Client) GetSMTPConfigurationContext(ctx context.Context, name string) (*SMTPConfiguration, error) {
+	domain, err := c.GetDomainContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	configuration := domain.SMTPConfiguration()
+
+	return &configuration, nil
+}
+
+// CheckSMTP asks the API to verify the outbound SMTP records of a domain and returns the
+// updated configuration. Unlike VerifySMTP, missing records are not an error: they are
+// reported in Status, with the explanation of the API in Status.Message.
+func (c *Client) CheckSMTP(name string, opts ...RequestOption) (*SMTPConfiguration, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.CheckSMTPContext(ctx, name)
+}
+
+func (c *Client) CheckSMTPContext(ctx context.Context, name string) (*SMTPConfiguration, error) {
+	message, err := c.VerifySMTPContext(ctx, name)
+	if err != nil {
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+			return nil, err
+		}
+		message = apiErr.Message
+	}
+
+	configuration, err := c.GetSMTPConfigurationContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	configuration.Status.Message = message
+
+	return configuration, nil
+}
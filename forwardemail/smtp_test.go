@@ -0,0 +1,86 @@
+package forwardemail
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_CheckSMTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		verifyCode int
+		verifyBody string
+		want       *SMTPConfiguration
+		wantErr    bool
+	}{
+		{
+			name:       "verified",
+			verifyCode: http.StatusOK,
+			verifyBody: `"Domain's DNS records have been verified."`,
+			want: &SMTPConfiguration{
+				Domain:     "stark.com",
+				Enabled:    true,
+				DKIM:       &DNSRecord{Type: "TXT", Name: "fe-4e4d6c332b._domainkey.stark.com", Value: "v=DKIM1; k=rsa; p=MIGfMA0GCSq;", TTL: 3600},
+				ReturnPath: &DNSRecord{Type: "CNAME", Name: "fe-bounces.stark.com", Value: "forwardemail.net", TTL: 3600},
+				DMARC:      DNSRecord{Type: "TXT", Name: "_dmarc.stark.com", Value: "v=DMARC1; p=reject; pct=100;", TTL: 3600},
+				Status:     SMTPStatus{DKIM: true, ReturnPath: true, DMARC: false, Message: "Domain's DNS records have been verified."},
+			},
+		},
+		{
+			name:       "missing records are not an error",
+			verifyCode: http.StatusBadRequest,
+			verifyBody: `{"message": "DMARC record was not found."}`,
+			want: &SMTPConfiguration{
+				Domain:     "stark.com",
+				Enabled:    true,
+				DKIM:       &DNSRecord{Type: "TXT", Name: "fe-4e4d6c332b._domainkey.stark.com", Value: "v=DKIM1; k=rsa; p=MIGfMA0GCSq;", TTL: 3600},
+				ReturnPath: &DNSRecord{Type: "CNAME", Name: "fe-bounces.stark.com", Value: "forwardemail.net", TTL: 3600},
+				DMARC:      DNSRecord{Type: "TXT", Name: "_dmarc.stark.com", Value: "v=DMARC1; p=reject; pct=100;", TTL: 3600},
+				Status:     SMTPStatus{DKIM: true, ReturnPath: true, DMARC: false, Message: "DMARC record was not found."},
+			},
+		},
+		{
+			name:       "other errors",
+			verifyCode: http.StatusUnauthorized,
+			verifyBody: `{"message": "Invalid API token."}`,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v1/domains/stark.com/verify-smtp":
+					w.WriteHeader(tt.verifyCode)
+					fmt.Fprint(w, tt.verifyBody)
+				case "/v1/domains/stark.com":
+					fmt.Fprint(w, `{"name": "stark.com", "has_smtp": true, "dkim_key_selector": "fe-4e4d6c332b", "dkim_public_key": "MIGfMA0GCSq", "return_path": "fe-bounces", "has_dkim_record": true, "has_return_path_record": true, "has_dmarc_record": false}`)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, err := c.CheckSMTP("stark.com")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+			if got != nil && got.Status.Verified() {
+				t.Error("expected the status to not be verified")
+			}
+		})
+	}
+}
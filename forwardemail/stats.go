@@ -0,0 +1,121 @@
+package forwardemail
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Stats receives a measurement of every request sent to the API, retries included, to feed a
+// metrics system such as Prometheus without this package depending on it: count the requests
+// by RequestStats.Endpoint and StatusClass, the retries with Retry, and observe Duration in a
+// latency histogram. RequestDone is called from the goroutine sending the request, so
+// implementations must be safe for concurrent use.
+type Stats interface {
+	RequestDone(RequestStats)
+}
+
+// StatsFunc adapts a function to Stats.
+type StatsFunc func(RequestStats)
+
+func (f StatsFunc) RequestDone(s RequestStats) {
+	f(s)
+}
+
+// RequestStats describes a request sent to the API.
+type RequestStats struct {
+	Method string
+	// Endpoint is the path of the request with identifiers replaced by placeholders, e.g.
+	// "/v1/domains/{domain}/aliases", fit for a metric label. See Route.
+	Endpoint string
+	// StatusCode is zero when no response was received, in which case Err is set.
+	StatusCode int
+	Err        error
+	// Attempt is the attempt number of the request, starting at 1.
+	Attempt  int
+	Duration time.Duration
+}
+
+// StatusClass returns the class of the status code, "2xx" to "5xx", or "error" when no
+// response was received.
+func (s RequestStats) StatusClass() string {
+	if s.StatusCode == 0 {
+		return "error"
+	}
+
+	return strconv.Itoa(s.StatusCode/100) + "xx"
+}
+
+// Retry reports whether the request was a retry of a failed attempt.
+func (s RequestStats) Retry() bool {
+	return s.Attempt > 1
+}
+
+// WithStats reports every request sent by the client to stats.
+func WithStats(stats Stats) Option {
+	return func(c *Client) {
+		c.stats = stats
+	}
+}
+
+func (c *Client) recordStats(req *http.Request, res *http.Response, err error, duration time.Duration) {
+	if c.stats == nil {
+		return
+	}
+
+	endpoint, _ := Route(req.URL.EscapedPath())
+	s := RequestStats{
+		Method:   req.Method,
+		Endpoint: endpoint,
+		Err:      err,
+		Attempt:  Attempt(req.Context()),
+		Duration: duration,
+	}
+	if res != nil {
+		s.StatusCode = res.StatusCode
+	}
+
+	c.stats.RequestDone(s)
+}
+
+// placeholders names the identifier following each collection of the API.
+var placeholders = map[string]string{
+	"domains":             "{domain}",
+	"aliases":             "{alias}",
+	"members":             "{member}",
+	"catch-all-passwords": "{id}",
+	"emails":              "{id}",
+	"messages":            "{id}",
+	"folders":             "{id}",
+	"contacts":            "{id}",
+	"calendars":           "{id}",
+	"calendar-events":     "{id}",
+}
+
+// staticSegments are fixed segments found where an identifier would be expected.
+var staticSegments = map[string]bool{
+	"limit":    true,
+	"download": true,
+}
+
+// Route turns a request path into a low-cardinality endpoint by replacing identifiers with
+// placeholders, e.g. "/v1/domains/{domain}/aliases/{alias}", and returns the domain name
+// found in the path, if any.
+func Route(path string) (endpoint string, domain string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		placeholder, ok := placeholders[segments[i-1]]
+		if !ok || staticSegments[segments[i]] {
+			continue
+		}
+
+		if placeholder == "{domain}" {
+			domain = segments[i]
+		}
+		segments[i] = placeholder
+		i++
+	}
+
+	return "/" + strings.Join(segments, "/"), domain
+}
@@ -0,0 +1,98 @@
+package forwardemail
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithStats(t *testing.T) {
+	calls := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"message": "Service Unavailable"}`)
+			return
+		}
+		fmt.Fprint(w, `{"name": "tony"}`)
+	}))
+	defer svr.Close()
+
+	var got []string
+	c := NewClient(ClientOptions{
+		ApiUrl:      svr.URL,
+		RetryPolicy: &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	}, WithStats(StatsFunc(func(s RequestStats) {
+		if s.Duration <= 0 {
+			t.Errorf("expected a duration, got %v", s.Duration)
+		}
+		got = append(got, fmt.Sprintf("%s %s %s %v", s.Method, s.Endpoint, s.StatusClass(), s.Retry()))
+	})))
+
+	if _, err := c.GetAlias("stark.com", "tony"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"GET /v1/domains/{domain}/aliases/{alias} 5xx false",
+		"GET /v1/domains/{domain}/aliases/{alias} 2xx true",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+}
+
+func TestRequestStats_StatusClass(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       string
+	}{
+		{statusCode: 0, want: "error"},
+		{statusCode: 204, want: "2xx"},
+		{statusCode: 429, want: "4xx"},
+		{statusCode: 502, want: "5xx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := (RequestStats{StatusCode: tt.statusCode}).StatusClass(); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRoute(t *testing.T) {
+	tests := []struct {
+		path         string
+		wantEndpoint string
+		wantDomain   string
+	}{
+		{path: "/v1/account", wantEndpoint: "/v1/account"},
+		{path: "/v1/domains", wantEndpoint: "/v1/domains"},
+		{path: "/v1/domains/stark.com", wantEndpoint: "/v1/domains/{domain}", wantDomain: "stark.com"},
+		{path: "/v1/domains/stark.com/verify-records", wantEndpoint: "/v1/domains/{domain}/verify-records", wantDomain: "stark.com"},
+		{path: "/v1/domains/stark.com/aliases/tony/generate-password", wantEndpoint: "/v1/domains/{domain}/aliases/{alias}/generate-password", wantDomain: "stark.com"},
+		{path: "/v1/domains/stark.com/catch-all-passwords/1", wantEndpoint: "/v1/domains/{domain}/catch-all-passwords/{id}", wantDomain: "stark.com"},
+		{path: "/v1/emails/limit", wantEndpoint: "/v1/emails/limit"},
+		{path: "/v1/emails/6461f6e9", wantEndpoint: "/v1/emails/{id}"},
+		{path: "/v1/logs/download", wantEndpoint: "/v1/logs/download"},
+		{path: "/v1/messages/6489e2d4", wantEndpoint: "/v1/messages/{id}"},
+		{path: "/v1/folders/INBOX", wantEndpoint: "/v1/folders/{id}"},
+		{path: "/v1/calendar-events/e1", wantEndpoint: "/v1/calendar-events/{id}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			endpoint, domain := Route(tt.path)
+			if diff := cmp.Diff([]string{tt.wantEndpoint, tt.wantDomain}, []string{endpoint, domain}); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package forwardemail
+
+import "context"
+
+// AliasQuota is the IMAP storage usage of an alias, in bytes.
+type AliasQuota struct {
+	Used      int64
+	Max       int64
+	Available int64
+}
+
+// DomainStorage is the IMAP storage usage of a domain, in bytes.
+type DomainStorage struct {
+	Used             int64
+	MaxQuotaPerAlias int64
+}
+
+// GetAliasQuota reports how much of its mailbox quota an alias uses.
+func (c *Client) GetAliasQuota(domain string, alias string, opts ...RequestOption) (*AliasQuota, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetAliasQuotaContext(ctx, domain, alias)
+}
+
+func (c *Client) GetAliasQuotaContext(ctx context.Context, domain string, alias string) (*AliasQuota, error) {
+	item, err := c.GetAliasContext(ctx, domain, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	available := item.MaxQuota - item.StorageUsed
+	if available < 0 {
+		available = 0
+	}
+
+	return &AliasQuota{
+		Used:      item.StorageUsed,
+		Max:       item.MaxQuota,
+		Available: available,
+	}, nil
+}
+
+// GetDomainStorage reports how much storage the aliases of a domain use altogether.
+func (c *Client) GetDomainStorage(domain string, opts ...RequestOption) (*DomainStorage, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetDomainStorageContext(ctx, domain)
+}
+
+func (c *Client) GetDomainStorageContext(ctx context.Context, domain string) (*DomainStorage, error) {
+	item, err := c.GetDomainContext(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DomainStorage{
+		Used:             item.StorageUsed,
+		MaxQuotaPerAlias: item.MaxQuotaPerAlias,
+	}, nil
+}
+
+// Usage is the usage of a domain against the limits of its plan. The sending quota
+// is the one of the account, shared by all of its domains.
+type Usage struct {
+	Plan                  string
+	AliasCount            int
+	MaxRecipientsPerAlias int
+	StorageUsed           int64
+	MaxQuotaPerAlias      int64
+	EmailsSent            int
+	EmailLimit            int
+}
+
+// EmailsRemaining is the number of emails that can still be sent today.
+func (u Usage) EmailsRemaining() int {
+	if remaining := u.EmailLimit - u.EmailsSent; remaining > 0 {
+		return remaining
+	}
+
+	return 0
+}
+
+// GetDomainUsage combines the domain, its alias count and the sending limit into a Usage,
+// e.g. to alert before a limit is hit.
+func (c *Client) GetDomainUsage(domain string, opts ...RequestOption) (*Usage, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.GetDomainUsageContext(ctx, domain)
+}
+
+func (c *Client) GetDomainUsageContext(ctx context.Context, domain string) (*Usage, error) {
+	item, err := c.GetDomainContext(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	aliasCount, err := c.countAliases(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := c.GetEmailLimitContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Usage{
+		Plan:                  item.Plan,
+		AliasCount:            aliasCount,
+		MaxRecipientsPerAlias: item.MaxRecipientsPerAlias,
+		StorageUsed:           item.StorageUsed,
+		MaxQuotaPerAlias:      item.MaxQuotaPerAlias,
+		EmailsSent:            limit.Count,
+		EmailLimit:            limit.Limit,
+	}, nil
+}
+
+// countAliases reads the alias count from the pagination headers of a single-item page,
+// falling back to listing every alias when the API doesn't report it.
+func (c *Client) countAliases(ctx context.Context, domain string) (int, error) {
+	items, pagination, err := c.GetAliasesPageContext(ctx, domain, ListAliasParameters{ListOptions: ListOptions{Limit: 1}})
+	if err != nil {
+		return 0, err
+	}
+	if pagination.ItemCount > 0 || len(items) == 0 {
+		return pagination.ItemCount, nil
+	}
+
+	all, err := c.GetAllAliasesContext(ctx, domain, ListAliasParameters{})
+
+	return len(all), err
+}
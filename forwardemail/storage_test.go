@@ -0,0 +1,152 @@
+package forwardemail
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_GetAliasQuota(t *testing.T) {
+	tests := []struct {
+		name string
+		res  string
+		want *AliasQuota
+	}{
+		{
+			name: "no data",
+		},
+		{
+			name: "ok",
+			res:  `{"name": "tony", "max_quota": 10737418240, "storage_used": 1073741824}`,
+			want: &AliasQuota{
+				Used:      1073741824,
+				Max:       10737418240,
+				Available: 9663676416,
+			},
+		},
+		{
+			name: "over quota",
+			res:  `{"name": "tony", "max_quota": 1024, "storage_used": 2048}`,
+			want: &AliasQuota{
+				Used: 2048,
+				Max:  1024,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.res)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, _ := c.GetAliasQuota("stark.com", "tony")
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_GetDomainStorage(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "stark.com", "max_quota_per_alias": 10737418240, "storage_used": 5368709120}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	got, _ := c.GetDomainStorage("stark.com")
+
+	want := &DomainStorage{
+		Used:             5368709120,
+		MaxQuotaPerAlias: 10737418240,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestClient_GetDomainUsage(t *testing.T) {
+	tests := []struct {
+		name      string
+		itemCount string
+		want      *Usage
+	}{
+		{
+			name:      "alias count from headers",
+			itemCount: "42",
+			want: &Usage{
+				Plan:                  "enhanced_protection",
+				AliasCount:            42,
+				MaxRecipientsPerAlias: 50,
+				StorageUsed:           2048,
+				MaxQuotaPerAlias:      10737418240,
+				EmailsSent:            120,
+				EmailLimit:            300,
+			},
+		},
+		{
+			name: "alias count from the list",
+			want: &Usage{
+				Plan:                  "enhanced_protection",
+				AliasCount:            2,
+				MaxRecipientsPerAlias: 50,
+				StorageUsed:           2048,
+				MaxQuotaPerAlias:      10737418240,
+				EmailsSent:            120,
+				EmailLimit:            300,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v1/domains/stark.com":
+					fmt.Fprint(w, `{"name": "stark.com", "plan": "enhanced_protection", "max_recipients_per_alias": 50, "storage_used": 2048, "max_quota_per_alias": 10737418240}`)
+				case "/v1/domains/stark.com/aliases":
+					if tt.itemCount != "" {
+						w.Header().Set("X-Item-Count", tt.itemCount)
+					}
+					if r.URL.Query().Get("limit") == "1" {
+						fmt.Fprint(w, `[{"name": "tony"}]`)
+						return
+					}
+					fmt.Fprint(w, `[{"name": "tony"}, {"name": "pepper"}]`)
+				case "/v1/emails/limit":
+					fmt.Fprint(w, `{"count": 120, "limit": 300}`)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, err := c.GetDomainUsage("stark.com")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+			if diff := cmp.Diff(180, got.EmailsRemaining()); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
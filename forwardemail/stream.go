@@ -0,0 +1,90 @@
+package forwardemail
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// doStreamRequest is like doRequestWithHeader but hands the body of a successful response to
+// decode as it is read, instead of buffering it. With a cache configured the body is buffered
+// anyway, since only complete bodies can be cached.
+func (c *Client) doStreamRequest(req *http.Request, decode func(io.Reader) error) (http.Header, error) {
+	if c.cache != nil {
+		body, header, err := c.doCachedRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		return header, decode(bytes.NewReader(body))
+	}
+
+	res, err := c.DoRaw(req)
+	if err != nil {
+		return nil, err
+	}
+	// The decoder stops at the end of the JSON value, or earlier on errors.
+	defer drainAndClose(res.Body)
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, c.newAPIError(res, body)
+	}
+
+	if err := decode(res.Body); err != nil {
+		return nil, err
+	}
+
+	return res.Header, nil
+}
+
+// decodeJSONArray decodes the items of a JSON array one at a time, calling fn for each of them.
+// Like json.Unmarshal, an empty body is an error and null has no items; isArray tells them apart
+// from an empty array.
+func decodeJSONArray[T any](dec *json.Decoder, fn func(T) error) (isArray bool, err error) {
+	token, err := dec.Token()
+	if err == io.EOF {
+		return false, io.ErrUnexpectedEOF
+	}
+	if err != nil || token == nil {
+		return false, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return false, fmt.Errorf("expected a JSON array, got %v", token)
+	}
+
+	for dec.More() {
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return true, err
+		}
+		if err := fn(item); err != nil {
+			return true, err
+		}
+	}
+
+	_, err = dec.Token()
+
+	return true, err
+}
+
+// collectJSONArray decodes a JSON array into a slice without buffering the raw body.
+func collectJSONArray[T any](c *Client, items *[]T) func(io.Reader) error {
+	return func(r io.Reader) error {
+		isArray, err := decodeJSONArray(c.newDecoder(r), func(item T) error {
+			*items = append(*items, item)
+			return nil
+		})
+		if isArray && *items == nil {
+			*items = []T{}
+		}
+
+		return err
+	}
+}
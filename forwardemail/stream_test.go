@@ -0,0 +1,96 @@
+package forwardemail
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_collectJSONArray(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []int
+		wantErr bool
+	}{
+		{name: "empty body", wantErr: true},
+		{name: "null", body: `null`},
+		{name: "empty array", body: `[]`, want: []int{}},
+		{name: "items", body: ` [1, 2, 3] `, want: []int{1, 2, 3}},
+		{name: "object", body: `{"message": "nope"}`, wantErr: true},
+		{name: "truncated", body: `[1, 2`, want: []int{1, 2}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			err := collectJSONArray(&Client{}, &got)(strings.NewReader(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_GetAliasesFunc(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/domains/stark.com/aliases" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"statusCode": 404, "message": "Not Found"}`)
+			return
+		}
+
+		page := r.URL.Query().Get("page")
+		w.Header().Set("X-Page-Count", "2")
+		w.Header().Set("X-Page-Current", page)
+		if page == "1" {
+			fmt.Fprint(w, `[{"name": "tony"}, {"name": "pepper"}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"name": "happy"}]`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	var names []string
+	err := c.GetAliasesFunc("stark.com", func(alias Alias) error {
+		names = append(names, alias.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"tony", "pepper", "happy"}, names); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+
+	stop := errors.New("stop")
+	names = nil
+	err = c.GetAliasesFunc("stark.com", func(alias Alias) error {
+		names = append(names, alias.Name)
+		return stop
+	})
+	if err != stop {
+		t.Errorf("expected the callback error, got %v", err)
+	}
+	if diff := cmp.Diff([]string{"tony"}, names); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+
+	var apiErr *APIError
+	err = c.GetAliasesFunc("wayne.com", func(Alias) error { return nil })
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package forwardemail
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TimestampLayouts are the layouts tried in order when decoding a Timestamp. Layouts without
+// a time zone are read as UTC. Append to it to accept other formats; it must not be modified
+// while responses are being decoded.
+var TimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02",
+}
+
+// Timestamp is a time returned by the API. Unlike time.Time, it decodes null and empty
+// strings, which older records have, as the zero time, and accepts every layout of
+// TimestampLayouts. The zero Timestamp is encoded as null.
+type Timestamp struct {
+	time.Time
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid timestamp %s: %w", data, err)
+	}
+
+	parsed, err := parseTimestamp(value)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+
+	return nil
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return t.Time.MarshalJSON()
+}
+
+func parseTimestamp(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range TimestampLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", value)
+}
+
+// timePointer returns the time of t, or nil when t is nil or zero.
+func (t *Timestamp) timePointer() *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+
+	v := t.Time
+
+	return &v
+}
@@ -0,0 +1,63 @@
+package forwardemail
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTimestamp_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "null", data: `null`},
+		{name: "empty", data: `""`},
+		{name: "rfc3339", data: `"2023-10-10T20:12:46Z"`, want: time.Date(2023, 10, 10, 20, 12, 46, 0, time.UTC)},
+		{name: "fractional seconds", data: `"2023-10-10T20:12:46.588Z"`, want: time.Date(2023, 10, 10, 20, 12, 46, 588000000, time.UTC)},
+		{name: "offset", data: `"2023-10-10T22:12:46+02:00"`, want: time.Date(2023, 10, 10, 20, 12, 46, 0, time.UTC)},
+		{name: "no time zone", data: `"2023-10-10T20:12:46.5"`, want: time.Date(2023, 10, 10, 20, 12, 46, 500000000, time.UTC)},
+		{name: "date only", data: `"2023-12-24"`, want: time.Date(2023, 12, 24, 0, 0, 0, 0, time.UTC)},
+		{name: "invalid", data: `"yesterday"`, wantErr: true},
+		{name: "number", data: `1696968766`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Timestamp
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got.Time)
+			}
+		})
+	}
+}
+
+func TestTimestamp_Alias(t *testing.T) {
+	var alias Alias
+	err := json.Unmarshal([]byte(`{"name": "tony", "created_at": null, "updated_at": "", "vacation_responder": {"start_date": ""}}`), &alias)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !alias.CreatedAt.IsZero() || !alias.UpdatedAt.IsZero() || alias.VacationResponder.StartDate.timePointer() != nil {
+		t.Errorf("expected zero timestamps, got %+v", alias)
+	}
+
+	data, err := json.Marshal(struct {
+		Zero Timestamp `json:"zero"`
+		Set  Timestamp `json:"set"`
+	}{Set: Timestamp{time.Date(2023, 10, 10, 20, 12, 46, 0, time.UTC)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(`{"zero":null,"set":"2023-10-10T20:12:46Z"}`, string(data)); diff != "" {
+		t.Errorf("values are not the same %s", diff)
+	}
+}
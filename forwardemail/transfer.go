@@ -0,0 +1,282 @@
+package forwardemail
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// AliasFileFormat is the file format used by ExportAliases and ImportAliases.
+type AliasFileFormat string
+
+const (
+	// AliasFormatCSV has a header row with the columns of aliasCSVHeader. Recipients and
+	// labels are comma separated within their cell.
+	AliasFormatCSV AliasFileFormat = "csv"
+	// AliasFormatJSON is an array of objects with the same fields as the CSV columns.
+	// A missing is_enabled means enabled, like in the API.
+	AliasFormatJSON AliasFileFormat = "json"
+)
+
+var aliasCSVHeader = []string{"name", "recipients", "description", "labels", "is_enabled", "has_recipient_verification"}
+
+type aliasRecord struct {
+	Name                     string   `json:"name"`
+	Recipients               []string `json:"recipients"`
+	Description              string   `json:"description"`
+	Labels                   []string `json:"labels"`
+	IsEnabled                bool     `json:"is_enabled"`
+	HasRecipientVerification bool     `json:"has_recipient_verification"`
+}
+
+// ExportAliases writes every alias of a domain to w in the given format.
+func (c *Client) ExportAliases(domain string, w io.Writer, format AliasFileFormat, opts ...RequestOption) error {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.ExportAliasesContext(ctx, domain, w, format)
+}
+
+func (c *Client) ExportAliasesContext(ctx context.Context, domain string, w io.Writer, format AliasFileFormat) error {
+	items, err := c.GetAllAliasesContext(ctx, domain, ListAliasParameters{})
+	if err != nil {
+		return err
+	}
+
+	records := make([]aliasRecord, len(items))
+	for i, item := range items {
+		records[i] = aliasRecord{
+			Name:                     item.Name,
+			Recipients:               item.Recipients,
+			Description:              item.Description,
+			Labels:                   item.Labels,
+			IsEnabled:                item.IsEnabled,
+			HasRecipientVerification: item.HasRecipientVerification,
+		}
+	}
+
+	return writeAliasRecords(w, format, records)
+}
+
+// ImportOptions controls ImportAliases.
+type ImportOptions struct {
+	BulkOptions
+
+	// UpdateExisting updates the aliases that already exist instead of skipping them.
+	UpdateExisting bool
+	// DryRun only reports what would be created, updated and skipped.
+	DryRun bool
+}
+
+// ImportReport lists the names of the aliases handled by ImportAliases. Results holds the
+// outcome of every create and update, and is empty for dry runs.
+type ImportReport struct {
+	Created []string
+	Updated []string
+	// Skipped are the aliases repeated in the input, and the existing aliases left alone.
+	Skipped []string
+	Results BulkResults
+}
+
+// ImportAliases reads aliases in the given format from r and creates them on a domain. Names
+// are compared case-insensitively: only the first occurrence of a name in the input is used,
+// and aliases that already exist are skipped unless options.UpdateExisting is set.
+func (c *Client) ImportAliases(domain string, r io.Reader, format AliasFileFormat, options ImportOptions, opts ...RequestOption) (*ImportReport, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.ImportAliasesContext(ctx, domain, r, format, options)
+}
+
+func (c *Client) ImportAliasesContext(ctx context.Context, domain string, r io.Reader, format AliasFileFormat, options ImportOptions) (*ImportReport, error) {
+	records, err := readAliasRecords(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.GetAllAliasesContext(ctx, domain, ListAliasParameters{})
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]bool{}
+	for _, item := range items {
+		existing[strings.ToLower(item.Name)] = true
+	}
+
+	var report ImportReport
+	var creates, updates []BulkAlias
+	seen := map[string]bool{}
+	for _, record := range records {
+		key := strings.ToLower(record.Name)
+		if seen[key] || (existing[key] && !options.UpdateExisting) {
+			report.Skipped = append(report.Skipped, record.Name)
+			continue
+		}
+		seen[key] = true
+
+		alias := BulkAlias{Name: record.Name, Parameters: record.parameters()}
+		if existing[key] {
+			report.Updated = append(report.Updated, record.Name)
+			updates = append(updates, alias)
+		} else {
+			report.Created = append(report.Created, record.Name)
+			creates = append(creates, alias)
+		}
+	}
+
+	if options.DryRun {
+		return &report, nil
+	}
+
+	report.Results = append(report.Results, c.BulkCreateAliasesContext(ctx, domain, creates, options.BulkOptions)...)
+	report.Results = append(report.Results, c.BulkUpdateAliasesContext(ctx, domain, updates, options.BulkOptions)...)
+
+	return &report, nil
+}
+
+func (r aliasRecord) parameters() AliasParameters {
+	recipients, labels := r.Recipients, r.Labels
+	if recipients == nil {
+		recipients = []string{}
+	}
+	if labels == nil {
+		labels = []string{}
+	}
+
+	return AliasParameters{
+		Recipients:               &recipients,
+		Description:              r.Description,
+		Labels:                   &labels,
+		IsEnabled:                &r.IsEnabled,
+		HasRecipientVerification: &r.HasRecipientVerification,
+	}
+}
+
+func writeAliasRecords(w io.Writer, format AliasFileFormat, records []aliasRecord) error {
+	switch format {
+	case AliasFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	case AliasFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(aliasCSVHeader); err != nil {
+			return err
+		}
+		for _, record := range records {
+			err := cw.Write([]string{
+				record.Name,
+				strings.Join(record.Recipients, ","),
+				record.Description,
+				strings.Join(record.Labels, ","),
+				strconv.FormatBool(record.IsEnabled),
+				strconv.FormatBool(record.HasRecipientVerification),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	return fmt.Errorf("unknown alias file format %q", format)
+}
+
+func readAliasRecords(r io.Reader, format AliasFileFormat) ([]aliasRecord, error) {
+	switch format {
+	case AliasFormatJSON:
+		var raw []json.RawMessage
+		if err := json.NewDecoder(r).Decode(&raw); err != nil {
+			return nil, err
+		}
+		records := make([]aliasRecord, len(raw))
+		for i := range raw {
+			records[i].IsEnabled = true
+			if err := json.Unmarshal(raw[i], &records[i]); err != nil {
+				return nil, err
+			}
+			if records[i].Name == "" {
+				return nil, fmt.Errorf("alias %d has no name", i+1)
+			}
+		}
+		return records, nil
+	case AliasFormatCSV:
+		return readAliasCSV(r)
+	}
+
+	return nil, fmt.Errorf("unknown alias file format %q", format)
+}
+
+// readAliasCSV maps the columns by their header, so they may come in any order and
+// only the name column is required. Empty booleans get the defaults of the API.
+func readAliasCSV(r io.Reader) ([]aliasRecord, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{}
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("alias csv has no name column")
+	}
+
+	records := make([]aliasRecord, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		cell := func(column string) string {
+			if j, ok := columns[column]; ok && j < len(row) {
+				return strings.TrimSpace(row[j])
+			}
+			return ""
+		}
+		flag := func(column string, def bool) (bool, error) {
+			value := cell(column)
+			if value == "" {
+				return def, nil
+			}
+			return strconv.ParseBool(value)
+		}
+
+		record := aliasRecord{
+			Name:        cell("name"),
+			Recipients:  splitList(cell("recipients")),
+			Description: cell("description"),
+			Labels:      splitList(cell("labels")),
+		}
+		if record.Name == "" {
+			return nil, fmt.Errorf("alias csv line %d has no name", i+2)
+		}
+		if record.IsEnabled, err = flag("is_enabled", true); err != nil {
+			return nil, fmt.Errorf("alias csv line %d: %w", i+2, err)
+		}
+		if record.HasRecipientVerification, err = flag("has_recipient_verification", false); err != nil {
+			return nil, fmt.Errorf("alias csv line %d: %w", i+2, err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,241 @@
+package forwardemail
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const transferAliases = `[
+	{"name": "tony", "recipients": ["tony@stark.com", "ironman@stark.com"], "description": "main, email", "labels": ["work"], "is_enabled": true},
+	{"name": "pepper", "recipients": ["pepper@stark.com"], "is_enabled": false, "has_recipient_verification": true}
+]`
+
+func TestClient_ExportAliases(t *testing.T) {
+	tests := []struct {
+		name   string
+		format AliasFileFormat
+		want   string
+	}{
+		{
+			name:   "csv",
+			format: AliasFormatCSV,
+			want: "name,recipients,description,labels,is_enabled,has_recipient_verification\n" +
+				"tony,\"tony@stark.com,ironman@stark.com\",\"main, email\",work,true,false\n" +
+				"pepper,pepper@stark.com,,,false,true\n",
+		},
+		{
+			name:   "json",
+			format: AliasFormatJSON,
+			want: `[
+  {
+    "name": "tony",
+    "recipients": [
+      "tony@stark.com",
+      "ironman@stark.com"
+    ],
+    "description": "main, email",
+    "labels": [
+      "work"
+    ],
+    "is_enabled": true,
+    "has_recipient_verification": false
+  },
+  {
+    "name": "pepper",
+    "recipients": [
+      "pepper@stark.com"
+    ],
+    "description": "",
+    "labels": null,
+    "is_enabled": false,
+    "has_recipient_verification": true
+  }
+]
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, transferAliases)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			var buf bytes.Buffer
+			if err := c.ExportAliases("stark.com", &buf, tt.format); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, buf.String()); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_ImportAliases(t *testing.T) {
+	const input = "name,recipients,is_enabled\n" +
+		"tony,tony@stark.com,\n" +
+		"happy,\"happy@stark.com, hogan@stark.com\",false\n" +
+		"Happy,happy@stark.com,\n" +
+		"rhodey,james@rhodes.com,\n"
+
+	tests := []struct {
+		name        string
+		options     ImportOptions
+		want        *ImportReport
+		wantBodies  []string
+		wantResults int
+	}{
+		{
+			name: "skips existing and duplicates",
+			want: &ImportReport{
+				Created: []string{"happy", "rhodey"},
+				Skipped: []string{"tony", "Happy"},
+			},
+			wantBodies: []string{
+				`POST {"name":"happy","recipients":["happy@stark.com","hogan@stark.com"],"labels":[],"has_recipient_verification":false,"is_enabled":false}`,
+				`POST {"name":"rhodey","recipients":["james@rhodes.com"],"labels":[],"has_recipient_verification":false,"is_enabled":true}`,
+			},
+			wantResults: 2,
+		},
+		{
+			name:    "updates existing",
+			options: ImportOptions{UpdateExisting: true},
+			want: &ImportReport{
+				Created: []string{"happy", "rhodey"},
+				Updated: []string{"tony"},
+				Skipped: []string{"Happy"},
+			},
+			wantBodies: []string{
+				`POST {"name":"happy","recipients":["happy@stark.com","hogan@stark.com"],"labels":[],"has_recipient_verification":false,"is_enabled":false}`,
+				`POST {"name":"rhodey","recipients":["james@rhodes.com"],"labels":[],"has_recipient_verification":false,"is_enabled":true}`,
+				`PUT {"recipients":["tony@stark.com"],"labels":[],"has_recipient_verification":false,"is_enabled":true}`,
+			},
+			wantResults: 3,
+		},
+		{
+			name:    "dry run",
+			options: ImportOptions{DryRun: true, UpdateExisting: true},
+			want: &ImportReport{
+				Created: []string{"happy", "rhodey"},
+				Updated: []string{"tony"},
+				Skipped: []string{"Happy"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies []string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet && r.URL.Path == "/v1/domains/stark.com/aliases" {
+					fmt.Fprint(w, transferAliases)
+					return
+				}
+				if r.Method != http.MethodGet {
+					var body bytes.Buffer
+					_, _ = body.ReadFrom(r.Body)
+					bodies = append(bodies, r.Method+" "+body.String())
+				}
+
+				fmt.Fprint(w, `{"name": "tony", "recipients": ["tony@stark.com"], "is_enabled": true}`)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, err := c.ImportAliases("stark.com", strings.NewReader(input), AliasFormatCSV, tt.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := got.Results.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.wantResults, len(got.Results)); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+
+			got.Results = nil
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+
+			sort.Strings(bodies)
+			if diff := cmp.Diff(tt.wantBodies, bodies); diff != "" {
+				t.Fatalf("request bodies are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func Test_readAliasRecords(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  AliasFileFormat
+		input   string
+		want    []aliasRecord
+		wantErr bool
+	}{
+		{
+			name:   "json defaults to enabled",
+			format: AliasFormatJSON,
+			input:  `[{"name": "tony"}, {"name": "pepper", "is_enabled": false}]`,
+			want:   []aliasRecord{{Name: "tony", IsEnabled: true}, {Name: "pepper"}},
+		},
+		{
+			name:    "json without name",
+			format:  AliasFormatJSON,
+			input:   `[{"recipients": ["tony@stark.com"]}]`,
+			wantErr: true,
+		},
+		{
+			name:   "csv in any column order",
+			format: AliasFormatCSV,
+			input:  "labels,Name\n\"work, home\",tony\n",
+			want:   []aliasRecord{{Name: "tony", Labels: []string{"work", "home"}, IsEnabled: true}},
+		},
+		{
+			name:    "csv without name column",
+			format:  AliasFormatCSV,
+			input:   "recipients\ntony@stark.com\n",
+			wantErr: true,
+		},
+		{
+			name:    "csv with an invalid boolean",
+			format:  AliasFormatCSV,
+			input:   "name,is_enabled\ntony,maybe\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown format",
+			format:  "xml",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readAliasRecords(strings.NewReader(tt.input), tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
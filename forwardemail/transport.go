@@ -0,0 +1,127 @@
+package forwardemail
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// defaultHTTPClient is the HTTP client used unless WithHTTPClient says otherwise. It is
+// shared by the clients, which then share its connection pool to the API.
+var defaultHTTPClient = &http.Client{Transport: newDefaultTransport()}
+
+// newDefaultTransport tunes http.DefaultTransport for many requests to a single host: more
+// keep-alive connections are kept to it and TLS sessions are resumed between connections.
+func newDefaultTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = 16
+	transport.IdleConnTimeout = 90 * time.Second
+	transport.ForceAttemptHTTP2 = true
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ClientSessionCache: tls.NewLRUClientSessionCache(0),
+	}
+
+	return transport
+}
+
+// CloseIdleConnections closes the keep-alive connections of the HTTP client in use that
+// aren't carrying a request, e.g. at the end of a sync or before the process goes idle. The
+// connections of the default HTTP client are shared between clients.
+func (c *Client) CloseIdleConnections() {
+	c.HttpClient.CloseIdleConnections()
+}
+
+// maxDrainBytes bounds how much of an unread response body is discarded before closing it so
+// its connection can be reused. Past that, a new connection is cheaper than reading on.
+const maxDrainBytes = 64 << 10
+
+// drainAndClose reads what is left of a response body and closes it, which lets the
+// transport reuse the connection: closing a body that wasn't read to its end drops it, at
+// least with the Go releases that don't drain small bodies themselves.
+func drainAndClose(body io.ReadCloser) {
+	_, _ = io.CopyN(io.Discard, body, maxDrainBytes)
+	body.Close()
+}
+
+// TransportOptions tunes the connection pool of the client. Zero values keep the
+// settings of the transport in use.
+type TransportOptions struct {
+	// MaxIdleConnsPerHost is the number of keep-alive connections kept to the API. The
+	// default HTTP client keeps 16; raise it when more requests than that are usually in
+	// flight at once, or connections get closed and reopened between them.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost caps the number of connections to the API, idle or not.
+	MaxConnsPerHost int
+
+	IdleConnTimeout       time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+
+	// DisableHTTP2 sticks to HTTP/1.1, spreading parallel requests over several
+	// connections instead of multiplexing them over a single one.
+	DisableHTTP2 bool
+}
+
+// WithTransportOptions tunes the transport of the HTTP client in use, which must be an
+// *http.Transport, or nil for http.DefaultTransport. Any other http.RoundTripper, such as the
+// one installed by otelforwardemail, can't be tuned: every request of the client then fails
+// with the error saying so, so put WithTransportOptions first. Neither the previous transport
+// nor the previous HTTP client are modified, so it is safe to combine with a shared client,
+// but it must come after WithHTTPClient.
+func WithTransportOptions(options TransportOptions) Option {
+	return func(c *Client) {
+		c.tuneTransport("WithTransportOptions", func(transport *http.Transport) {
+			tuneTransport(transport, options)
+		})
+	}
+}
+
+// tuneTransport replaces the transport of the HTTP client in use by a clone changed with
+// tune, recording an error for the requests when the transport isn't an *http.Transport.
+func (c *Client) tuneTransport(option string, tune func(*http.Transport)) {
+	var base *http.Transport
+	switch transport := c.HttpClient.Transport.(type) {
+	case nil:
+		base = http.DefaultTransport.(*http.Transport)
+	case *http.Transport:
+		base = transport
+	default:
+		c.optionErr = fmt.Errorf("forwardemail: %s can't tune a transport of type %T, only an *http.Transport", option, transport)
+		return
+	}
+
+	transport := base.Clone()
+	tune(transport)
+
+	httpClient := *c.HttpClient
+	httpClient.Transport = transport
+	c.HttpClient = &httpClient
+}
+
+func tuneTransport(transport *http.Transport, options TransportOptions) {
+	if options.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = options.MaxIdleConnsPerHost
+		if transport.MaxIdleConns > 0 && transport.MaxIdleConns < options.MaxIdleConnsPerHost {
+			transport.MaxIdleConns = options.MaxIdleConnsPerHost
+		}
+	}
+	if options.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = options.MaxConnsPerHost
+	}
+	if options.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = options.IdleConnTimeout
+	}
+	if options.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = options.TLSHandshakeTimeout
+	}
+	if options.ResponseHeaderTimeout > 0 {
+		transport.ResponseHeaderTimeout = options.ResponseHeaderTimeout
+	}
+	if options.DisableHTTP2 {
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+}
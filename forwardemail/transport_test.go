@@ -0,0 +1,210 @@
+package forwardemail
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithTransportOptions(t *testing.T) {
+	c := NewClient(ClientOptions{}, WithTransportOptions(TransportOptions{
+		MaxIdleConnsPerHost:   64,
+		MaxConnsPerHost:       128,
+		IdleConnTimeout:       time.Minute,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+		DisableHTTP2:          true,
+	}))
+
+	if c.HttpClient == http.DefaultClient || http.DefaultClient.Transport != nil {
+		t.Fatal("http.DefaultClient was modified")
+	}
+
+	transport, ok := c.HttpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("unexpected transport %T", c.HttpClient.Transport)
+	}
+
+	got := []any{
+		transport.MaxIdleConnsPerHost,
+		transport.MaxConnsPerHost,
+		transport.IdleConnTimeout,
+		transport.TLSHandshakeTimeout,
+		transport.ResponseHeaderTimeout,
+		transport.ForceAttemptHTTP2,
+		transport.TLSNextProto != nil && len(transport.TLSNextProto) == 0,
+	}
+	want := []any{64, 128, time.Minute, 5 * time.Second, 10 * time.Second, false, true}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+func TestWithTransportOptions_KeepsCustomTransport(t *testing.T) {
+	base := &http.Transport{MaxIdleConns: 7}
+	c := NewClient(ClientOptions{},
+		WithHTTPClient(&http.Client{Transport: base}),
+		WithTransportOptions(TransportOptions{MaxIdleConnsPerHost: 4}),
+	)
+
+	transport := c.HttpClient.Transport.(*http.Transport)
+	if transport == base || base.MaxIdleConnsPerHost != 0 {
+		t.Fatal("the custom transport was modified")
+	}
+	if diff := cmp.Diff([]int{7, 4}, []int{transport.MaxIdleConns, transport.MaxIdleConnsPerHost}); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestWithTransportOptions_OtherRoundTripper(t *testing.T) {
+	var calls int
+	base := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return nil, fmt.Errorf("unexpected request")
+	})
+	c := NewClient(ClientOptions{},
+		WithHTTPClient(&http.Client{Transport: base}),
+		WithTransportOptions(TransportOptions{MaxIdleConnsPerHost: 4}),
+	)
+
+	if _, ok := c.HttpClient.Transport.(roundTripperFunc); !ok {
+		t.Fatalf("the custom round tripper was replaced by %T", c.HttpClient.Transport)
+	}
+
+	_, err := c.GetAccount()
+	want := "forwardemail: WithTransportOptions can't tune a transport of type forwardemail.roundTripperFunc, only an *http.Transport"
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("expected no calls, got %d", calls)
+	}
+}
+
+func TestClient_ConcurrentUse(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "1000")
+		w.Header().Set("X-RateLimit-Remaining", "999")
+		fmt.Fprint(w, `{"name": "tony"}`)
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	}, WithTransportOptions(TransportOptions{MaxIdleConnsPerHost: 8}), WithCache(NewMemoryCache(), time.Minute))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			c.SetAPIKey(fmt.Sprintf("key-%d", i))
+			if _, err := c.GetAlias("stark.com", "tony"); err != nil {
+				t.Error(err)
+			}
+			if _, err := c.UpdateAlias("stark.com", "tony", AliasParameters{}); err != nil {
+				t.Error(err)
+			}
+			c.RateLimit()
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestClient_ReusesConnections(t *testing.T) {
+	var mu sync.Mutex
+	var connections int
+	calls := 0
+	svr := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		call := calls
+		mu.Unlock()
+
+		switch {
+		case call == 1:
+			// A retried error, whose body must be discarded before the next attempt.
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"message": "Service Unavailable"}`)
+		case r.URL.Path == "/v1/domains/stark.com/aliases":
+			// Trailing data left unread by the JSON decoder.
+			fmt.Fprint(w, `[{"name": "tony"}]`+strings.Repeat(" ", 16<<10))
+		default:
+			fmt.Fprint(w, `{"name": "tony"}`)
+		}
+	}))
+	svr.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			connections++
+			mu.Unlock()
+		}
+	}
+	svr.Start()
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl:      svr.URL,
+		RetryPolicy: &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	}, WithHTTPClient(&http.Client{Transport: newDefaultTransport()}))
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetAlias("stark.com", "tony"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.GetAliases("stark.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mu.Lock()
+	got := connections
+	mu.Unlock()
+	if got != 1 {
+		t.Errorf("expected a single connection, got %d", got)
+	}
+
+	c.CloseIdleConnections()
+	if _, err := c.GetAlias("stark.com", "tony"); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	got = connections
+	mu.Unlock()
+	if got != 2 {
+		t.Errorf("expected a new connection once the idle ones are closed, got %d connections", got)
+	}
+}
+
+func TestNewDefaultTransport(t *testing.T) {
+	transport := newDefaultTransport()
+	if transport == http.DefaultTransport {
+		t.Fatal("http.DefaultTransport is used as is")
+	}
+
+	got := []any{
+		transport.MaxIdleConnsPerHost,
+		transport.ForceAttemptHTTP2,
+		transport.TLSClientConfig.ClientSessionCache != nil,
+		transport.Proxy != nil,
+	}
+	want := []any{16, true, true, true}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("values are not the same %s", diff)
+	}
+}
@@ -0,0 +1,129 @@
+package forwardemail
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// maxLabelLength is the longest label accepted on an alias.
+const maxLabelLength = 255
+
+// ValidationError is returned before a request is sent when its parameters would be rejected
+// by the API. It lists every problem found rather than only the first one. An *APIError with
+// field-level problems also unwraps to a ValidationError.
+type ValidationError struct {
+	Problems []FieldError
+}
+
+// FieldError is a single problem found by client-side validation.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		problems[i] = p.Field + ": " + p.Message
+	}
+
+	return "invalid parameters: " + strings.Join(problems, "; ")
+}
+
+// validator collects field errors and turns them into a *ValidationError.
+type validator struct {
+	problems []FieldError
+}
+
+func (v *validator) addf(field string, format string, args ...any) {
+	v.problems = append(v.problems, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Problems: v.problems}
+}
+
+func validateAlias(name string, parameters AliasParameters) error {
+	var v validator
+
+	switch {
+	case name == "":
+		v.addf("name", "must not be empty")
+	case strings.HasPrefix(name, "/"):
+		if err := ValidateRegexAliasName(name); err != nil {
+			v.addf("name", "%s", err)
+		}
+	}
+
+	if parameters.Recipients != nil {
+		for i, recipient := range *parameters.Recipients {
+			if _, err := ParseRecipient(recipient); err != nil {
+				v.addf(fmt.Sprintf("recipients[%d]", i), "%s", err)
+			}
+		}
+	}
+
+	if parameters.ErrorCodeIfDisabled != nil && !parameters.ErrorCodeIfDisabled.Valid() {
+		v.addf("error_code_if_disabled", "%d is not one of %d, %d or %d", *parameters.ErrorCodeIfDisabled,
+			ErrorCodeAccept, ErrorCodeSoftReject, ErrorCodeHardReject)
+	}
+
+	if parameters.Labels != nil {
+		for i, label := range *parameters.Labels {
+			if len(label) > maxLabelLength {
+				v.addf(fmt.Sprintf("labels[%d]", i), "is longer than %d characters", maxLabelLength)
+			}
+		}
+	}
+
+	if len(parameters.Clear) > 0 {
+		fields := aliasFields()
+		set, err := json.Marshal(parameters)
+		if err != nil {
+			return err
+		}
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(set, &values); err != nil {
+			return err
+		}
+
+		for _, name := range parameters.Clear {
+			switch {
+			case !fields[name]:
+				v.addf("clear", "%q is not an alias field", name)
+			case values[name] != nil:
+				v.addf(name, "must not be both set and cleared")
+			}
+		}
+	}
+
+	return v.err()
+}
+
+func (p GeneratePasswordParameters) validate() error {
+	var v validator
+
+	if p.NewPassword != nil && *p.NewPassword == "" {
+		v.addf("new_password", "must not be empty")
+	}
+	if p.NewPassword != nil && p.LocalPassword != nil {
+		v.addf("new_password", "cannot be combined with a locally generated password")
+	}
+	if p.Password != nil && p.IsOverride != nil && *p.IsOverride {
+		v.addf("password", "cannot be combined with is_override, which discards the current password")
+	}
+	if p.EmailedInstructions != nil {
+		addr, err := mail.ParseAddress(*p.EmailedInstructions)
+		if err != nil || addr.Name != "" {
+			v.addf("emailed_instructions", "%q is not a valid email address", *p.EmailedInstructions)
+		}
+	}
+
+	return v.err()
+}
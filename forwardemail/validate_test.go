@@ -0,0 +1,182 @@
+package forwardemail
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_validateAlias(t *testing.T) {
+	tests := []struct {
+		name       string
+		alias      string
+		parameters AliasParameters
+		want       []FieldError
+	}{
+		{
+			name:  "valid",
+			alias: "james",
+			parameters: AliasParameters{
+				Recipients: pointSliceOfStrings([]string{"tony@stark.com", "https://example.com/hook", "mx.example.com"}),
+				Labels:     pointSliceOfStrings([]string{"work"}),
+			},
+		},
+		{
+			name:  "soft reject when disabled",
+			alias: "james",
+			parameters: AliasParameters{
+				ErrorCodeIfDisabled: pointErrorCode(ErrorCodeSoftReject),
+			},
+		},
+		{
+			name:  "catch-all",
+			alias: CatchAllAliasName,
+		},
+		{
+			name:  "cleared fields",
+			alias: "james",
+			parameters: AliasParameters{
+				Labels: pointSliceOfStrings([]string{"work"}),
+				Clear:  []string{"description", "vacation_responder_end_date"},
+			},
+		},
+		{
+			name:  "fields set and cleared or unknown",
+			alias: "james",
+			parameters: AliasParameters{
+				Description: "main email",
+				Clear:       []string{"description", "name", "Labels"},
+			},
+			want: []FieldError{
+				{Field: "description"},
+				{Field: "clear"},
+				{Field: "clear"},
+			},
+		},
+		{
+			name:  "empty name",
+			alias: "",
+			want:  []FieldError{{Field: "name", Message: "must not be empty"}},
+		},
+		{
+			name:  "invalid regex",
+			alias: "/(/",
+			want:  []FieldError{{Field: "name"}},
+		},
+		{
+			name:  "every problem is listed",
+			alias: "james",
+			parameters: AliasParameters{
+				Recipients:          pointSliceOfStrings([]string{"tony@stark.com", "tony", "ftp://example.com"}),
+				ErrorCodeIfDisabled: pointErrorCode(404),
+				Labels:              pointSliceOfStrings([]string{"work", strings.Repeat("a", maxLabelLength+1)}),
+			},
+			want: []FieldError{
+				{Field: "recipients[1]"},
+				{Field: "recipients[2]"},
+				{Field: "error_code_if_disabled"},
+				{Field: "labels[1]"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAlias(tt.alias, tt.parameters)
+
+			var got []FieldError
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				got = validationErr.Problems
+			} else if err != nil {
+				t.Fatalf("unexpected error type %T", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got, cmp.Comparer(func(a, b FieldError) bool {
+				return a.Field == b.Field && (a.Message == "" || b.Message == "" || a.Message == b.Message)
+			})); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestGeneratePasswordParameters_validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters GeneratePasswordParameters
+		want       []string
+	}{
+		{
+			name:       "valid",
+			parameters: GeneratePasswordParameters{Password: pointString("old"), NewPassword: pointString("new")},
+		},
+		{
+			name:       "override",
+			parameters: GeneratePasswordParameters{IsOverride: pointBool(true)},
+		},
+		{
+			name:       "password with override",
+			parameters: GeneratePasswordParameters{Password: pointString("old"), IsOverride: pointBool(true)},
+			want:       []string{"password"},
+		},
+		{
+			name:       "new password with local generation",
+			parameters: GeneratePasswordParameters{NewPassword: pointString("new"), LocalPassword: &PasswordPolicy{}},
+			want:       []string{"new_password"},
+		},
+		{
+			name: "several problems",
+			parameters: GeneratePasswordParameters{
+				NewPassword:         pointString(""),
+				EmailedInstructions: pointString("Tony <tony@stark.com>"),
+			},
+			want: []string{"new_password", "emailed_instructions"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			var validationErr *ValidationError
+			if errors.As(tt.parameters.validate(), &validationErr) {
+				for _, p := range validationErr.Problems {
+					got = append(got, p.Field)
+				}
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_CreateAlias_Validation(t *testing.T) {
+	requests := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer svr.Close()
+
+	c := NewClient(ClientOptions{
+		ApiUrl: svr.URL,
+	})
+
+	_, err := c.CreateAlias("stark.com", "", AliasParameters{Recipients: pointSliceOfStrings([]string{"tony"})})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a *ValidationError, got %v", err)
+	}
+	if len(validationErr.Problems) != 2 {
+		t.Errorf("expected 2 problems, got %v", validationErr.Problems)
+	}
+	if requests != 0 {
+		t.Errorf("expected no request to be sent, got %d", requests)
+	}
+}
@@ -0,0 +1,165 @@
+package forwardemail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WaitOptions controls how the WaitFor helpers poll the API. Zero values fall back to the
+// defaults noted on each field.
+type WaitOptions struct {
+	// Timeout is the overall time allowed for the condition to hold, 10 minutes by default.
+	Timeout time.Duration
+	// Interval is the delay before the first new attempt, 10 seconds by default.
+	// It doubles after every failed attempt.
+	Interval time.Duration
+	// MaxInterval caps the delay between attempts, 2 minutes by default.
+	MaxInterval time.Duration
+	// Progress, when set, is called after every attempt where the condition didn't hold yet.
+	Progress func(WaitProgress)
+}
+
+// WaitProgress describes an attempt where the awaited condition didn't hold yet.
+type WaitProgress struct {
+	// Attempt counts the attempts made so far, starting at 1.
+	Attempt int
+	// Elapsed is the time spent waiting so far.
+	Elapsed time.Duration
+	// Next is the delay before the next attempt.
+	Next time.Duration
+	// Reason tells why the condition doesn't hold, e.g. the *APIError of a failed verification.
+	Reason error
+}
+
+// WaitForDomainVerified keeps verifying the DNS records of a domain until they have
+// propagated or the timeout is reached, and returns the verified domain. Only failed
+// verifications, answered with 400 Bad Request, are retried; other errors such as an invalid
+// API key or an unknown domain are returned right away.
+func (c *Client) WaitForDomainVerified(domain string, options WaitOptions, opts ...RequestOption) (*Domain, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.WaitForDomainVerifiedContext(ctx, domain, options)
+}
+
+func (c *Client) WaitForDomainVerifiedContext(ctx context.Context, domain string, options WaitOptions) (*Domain, error) {
+	err := c.poll(ctx, options, "domain "+domain+" was not verified", func(ctx context.Context) (error, error) {
+		_, err := c.VerifyDomainRecordsContext(ctx, domain)
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+			return err, nil
+		}
+
+		return nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetDomainContext(ctx, domain)
+}
+
+// WaitForAliasActive polls an alias until it exists, is enabled and, when recipient
+// verification is on, all of its recipients are verified, and returns it. Errors other than
+// the alias not being found yet are returned right away.
+func (c *Client) WaitForAliasActive(domain string, alias string, options WaitOptions, opts ...RequestOption) (*Alias, error) {
+	ctx, cancel := requestContext(opts)
+	defer cancel()
+
+	return c.WaitForAliasActiveContext(ctx, domain, alias, options)
+}
+
+func (c *Client) WaitForAliasActiveContext(ctx context.Context, domain string, alias string, options WaitOptions) (*Alias, error) {
+	var item *Alias
+	err := c.poll(ctx, options, "alias "+alias+" was not active", func(ctx context.Context) (error, error) {
+		var err error
+		item, err = c.GetAliasContext(ctx, domain, alias)
+		if IsNotFound(err) {
+			return err, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return aliasInactiveReason(item), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func aliasInactiveReason(alias *Alias) error {
+	if !alias.IsEnabled {
+		return errors.New("alias is disabled")
+	}
+
+	var pending []string
+	for _, status := range alias.RecipientStatuses() {
+		if !status.Verified {
+			pending = append(pending, status.Recipient)
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("recipients are not verified: %s", strings.Join(pending, ", "))
+	}
+
+	return nil
+}
+
+// poll calls check with an exponential backoff until it reports no reason for the condition
+// not to hold, it fails, or the timeout is reached. The timeout error is described by what
+// and wraps the latest reason. The timeout is measured with the client clock; the context
+// deadline only bounds requests that hang.
+func (c *Client) poll(ctx context.Context, options WaitOptions, what string, check func(context.Context) (reason error, err error)) error {
+	if options.Timeout <= 0 {
+		options.Timeout = 10 * time.Minute
+	}
+	if options.Interval <= 0 {
+		options.Interval = 10 * time.Second
+	}
+	if options.MaxInterval <= 0 {
+		options.MaxInterval = 2 * time.Minute
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	start := c.clock.Now()
+	deadline := start.Add(options.Timeout)
+	interval := options.Interval
+	for attempt := 1; ; attempt++ {
+		reason, err := check(ctx)
+		if err != nil && ctx.Err() != nil {
+			return fmt.Errorf("%s in time: %w", what, err)
+		}
+		if err != nil {
+			return err
+		}
+		if reason == nil {
+			return nil
+		}
+
+		remaining := deadline.Sub(c.clock.Now())
+		if remaining <= 0 || ctx.Err() != nil {
+			return fmt.Errorf("%s in time: %w", what, reason)
+		}
+
+		wait := min(interval, remaining)
+		if options.Progress != nil {
+			options.Progress(WaitProgress{Attempt: attempt, Elapsed: c.clock.Now().Sub(start), Next: wait, Reason: reason})
+		}
+
+		if err := c.clock.Sleep(ctx, wait); err != nil {
+			return fmt.Errorf("%s in time: %w", what, reason)
+		}
+
+		interval = min(interval*2, options.MaxInterval)
+	}
+}
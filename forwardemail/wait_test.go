@@ -0,0 +1,176 @@
+package forwardemail
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var testWaitOptions = WaitOptions{
+	Timeout:     100 * time.Millisecond,
+	Interval:    time.Millisecond,
+	MaxInterval: 2 * time.Millisecond,
+}
+
+func TestClient_WaitForAliasActive(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []string
+		wantErr   bool
+		wantCalls int
+	}{
+		{
+			name: "created then verified",
+			responses: []string{
+				``,
+				`{"name": "tony", "is_enabled": true, "has_recipient_verification": true, "recipients": ["tony@stark.com"]}`,
+				`{"name": "tony", "is_enabled": true, "has_recipient_verification": true, "recipients": ["tony@stark.com"], "verified_recipients": ["Tony@stark.com"]}`,
+			},
+			wantCalls: 3,
+		},
+		{
+			name:      "active right away",
+			responses: []string{`{"name": "tony", "is_enabled": true, "recipients": ["tony@stark.com"]}`},
+			wantCalls: 1,
+		},
+		{
+			name:      "never enabled",
+			responses: []string{`{"name": "tony", "is_enabled": false}`},
+			wantErr:   true,
+		},
+		{
+			name:      "unauthorized",
+			responses: []string{`unauthorized`},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				res := tt.responses[min(calls, len(tt.responses)-1)]
+				calls++
+
+				switch res {
+				case ``:
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, `{"message": "Alias does not exist."}`)
+				case `unauthorized`:
+					w.WriteHeader(http.StatusUnauthorized)
+					fmt.Fprint(w, `{"message": "Invalid API token."}`)
+				default:
+					fmt.Fprint(w, res)
+				}
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			var progress []int
+			options := testWaitOptions
+			options.Progress = func(p WaitProgress) {
+				progress = append(progress, p.Attempt)
+			}
+
+			got, err := c.WaitForAliasActive("stark.com", "tony", options)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantCalls > 0 && calls != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, calls)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got.Name != "tony" {
+				t.Errorf("unexpected alias %+v", got)
+			}
+			var want []int
+			for i := 1; i < tt.wantCalls; i++ {
+				want = append(want, i)
+			}
+			if diff := cmp.Diff(want, progress); diff != "" {
+				t.Errorf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_WaitForDomainVerified(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int
+		wantErr   bool
+		wantCalls int
+	}{
+		{
+			name:      "verified after propagation",
+			statuses:  []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusOK},
+			wantCalls: 3,
+		},
+		{
+			name:      "unauthorized",
+			statuses:  []int{http.StatusUnauthorized},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		{
+			name:      "unknown domain",
+			statuses:  []int{http.StatusNotFound},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		{
+			name:     "never verified",
+			statuses: []int{http.StatusBadRequest},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v1/domains/stark.com" {
+					fmt.Fprint(w, `{"name": "stark.com", "has_mx_record": true, "has_txt_record": true}`)
+					return
+				}
+
+				status := tt.statuses[min(calls, len(tt.statuses)-1)]
+				calls++
+
+				w.WriteHeader(status)
+				if status == http.StatusOK {
+					fmt.Fprint(w, `"Domain's DNS records have been verified."`)
+					return
+				}
+				fmt.Fprint(w, `{"message": "Domain's DNS records are not verified yet."}`)
+			}))
+			defer svr.Close()
+
+			c := NewClient(ClientOptions{
+				ApiUrl: svr.URL,
+			})
+
+			got, err := c.WaitForDomainVerified("stark.com", testWaitOptions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantCalls > 0 && calls != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, calls)
+			}
+			if !tt.wantErr && got.Name != "stark.com" {
+				t.Errorf("unexpected domain %+v", got)
+			}
+		})
+	}
+}
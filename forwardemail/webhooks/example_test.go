@@ -0,0 +1,64 @@
+package webhooks_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/mattwebbio/go-forwardemail/forwardemail/webhooks"
+)
+
+func ExampleParseWebhook() {
+	const signingKey = "webhook-key"
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event, err := webhooks.ParseWebhook(r, signingKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		if event.Bounce != nil {
+			fmt.Println("bounce:", event.Bounce.Recipient, event.Bounce.Bounce.Category)
+		}
+		if event.Message != nil {
+			fmt.Println("message:", event.Message.Subject)
+		}
+	})
+
+	// Forward Email signs the body with the webhook key of the domain.
+	body := `{"recipient": "pepper@stark.com", "bounce": {"category": "block", "code": 550}}`
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(body))
+
+	req := httptest.NewRequest("POST", "/webhooks/forwardemail", strings.NewReader(body))
+	req.Header.Set(webhooks.SignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// A tampered body is rejected.
+	req = httptest.NewRequest("POST", "/webhooks/forwardemail", strings.NewReader(`{"subject": "forged"}`))
+	req.Header.Set(webhooks.SignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	fmt.Println(rec.Code)
+	// Output:
+	// bounce: pepper@stark.com block
+	// 401
+}
+
+func ExampleVerifySignature() {
+	body := []byte(`{"subject": "Hello"}`)
+	mac := hmac.New(sha256.New, []byte("webhook-key"))
+	mac.Write(body)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	fmt.Println(webhooks.VerifySignature(body, signature, "webhook-key"))
+	fmt.Println(webhooks.VerifySignature(body, signature, "another-key"))
+	// Output:
+	// true
+	// false
+}
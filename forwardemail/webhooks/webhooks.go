@@ -0,0 +1,165 @@
+// Package webhooks verifies and decodes the webhooks sent by Forward Email,
+// both bounce webhooks and messages forwarded to webhook recipients.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SignatureHeader carries the hex encoded HMAC-SHA256 of the request body, keyed with the webhook key.
+const SignatureHeader = "X-Webhook-Signature"
+
+// DefaultMaxBodySize is the largest body ParseWebhook reads. Forward Email forwards messages
+// of up to 50 MB, and the payload of a message carries it both raw and parsed, so the limit
+// leaves room for twice that and a bit more.
+const DefaultMaxBodySize = 128 << 20
+
+// ErrInvalidSignature is returned when the signature header is missing or doesn't match the body.
+var ErrInvalidSignature = errors.New("webhooks: invalid signature")
+
+// ErrNoSigningKey is returned when the signing key is empty: any body signed with an empty key
+// would pass, so nothing is accepted.
+var ErrNoSigningKey = errors.New("webhooks: no signing key")
+
+// ErrBodyTooLarge is returned when the request body is over the size limit, before its
+// signature is checked.
+var ErrBodyTooLarge = errors.New("webhooks: body too large")
+
+type Bounce struct {
+	Action   string `json:"action"`
+	Message  string `json:"message"`
+	Category string `json:"category"`
+	Code     int    `json:"code"`
+	Status   string `json:"status"`
+	Line     int    `json:"line"`
+}
+
+// BounceEvent is sent to a domain bounce webhook when an outbound or forwarded message bounces.
+type BounceEvent struct {
+	Email           string    `json:"email"`
+	ListId          string    `json:"list_id"`
+	ListUnsubscribe string    `json:"list_unsubscribe"`
+	FeedbackId      string    `json:"feedback_id"`
+	Recipient       string    `json:"recipient"`
+	Message         string    `json:"message"`
+	Response        string    `json:"response"`
+	ResponseCode    int       `json:"response_code"`
+	Headers         any       `json:"headers"`
+	Bounce          Bounce    `json:"bounce"`
+	MessageId       string    `json:"message_id"`
+	BouncedAt       time.Time `json:"bounced_at"`
+}
+
+type Address struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+}
+
+type AddressList struct {
+	Value []Address `json:"value"`
+	Html  string    `json:"html"`
+	Text  string    `json:"text"`
+}
+
+// MessageEvent is a message forwarded to a webhook recipient, as parsed by mailparser.
+type MessageEvent struct {
+	Subject    string      `json:"subject"`
+	From       AddressList `json:"from"`
+	To         AddressList `json:"to"`
+	Cc         AddressList `json:"cc"`
+	Date       time.Time   `json:"date"`
+	MessageId  string      `json:"messageId"`
+	Text       string      `json:"text"`
+	Html       string      `json:"html"`
+	Recipients []string    `json:"recipients"`
+	Raw        string      `json:"raw"`
+}
+
+// Event is a verified webhook payload. Exactly one of Bounce and Message is set.
+type Event struct {
+	Bounce  *BounceEvent
+	Message *MessageEvent
+
+	// Payload is the raw JSON body, for fields not covered by the typed events.
+	Payload json.RawMessage
+}
+
+// ParseWebhook reads the request body, checks it against the signature header and decodes it.
+// Bodies over DefaultMaxBodySize are rejected with ErrBodyTooLarge.
+func ParseWebhook(r *http.Request, signingKey string) (*Event, error) {
+	return ParseWebhookLimit(r, signingKey, DefaultMaxBodySize)
+}
+
+// ParseWebhookLimit is ParseWebhook reading at most maxBytes of the body.
+func ParseWebhookLimit(r *http.Request, signingKey string, maxBytes int64) (*Event, error) {
+	if signingKey == "" {
+		return nil, ErrNoSigningKey
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > maxBytes {
+		return nil, ErrBodyTooLarge
+	}
+
+	if !VerifySignature(body, r.Header.Get(SignatureHeader), signingKey) {
+		return nil, ErrInvalidSignature
+	}
+
+	return parsePayload(body)
+}
+
+// VerifySignature reports whether signature is the HMAC-SHA256 of body keyed with signingKey.
+// It is always false with an empty signingKey.
+func VerifySignature(body []byte, signature string, signingKey string) bool {
+	if signingKey == "" {
+		return false
+	}
+
+	got, err := hex.DecodeString(signature)
+	if err != nil || len(got) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write(body)
+
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+func parsePayload(body []byte) (*Event, error) {
+	var probe struct {
+		Bounce json.RawMessage `json:"bounce"`
+	}
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return nil, fmt.Errorf("webhooks: cannot decode payload: %w", err)
+	}
+
+	event := &Event{Payload: body}
+
+	if probe.Bounce != nil {
+		event.Bounce = &BounceEvent{}
+		if err := json.Unmarshal(body, event.Bounce); err != nil {
+			return nil, fmt.Errorf("webhooks: cannot decode bounce: %w", err)
+		}
+
+		return event, nil
+	}
+
+	event.Message = &MessageEvent{}
+	if err := json.Unmarshal(body, event.Message); err != nil {
+		return nil, fmt.Errorf("webhooks: cannot decode message: %w", err)
+	}
+
+	return event, nil
+}
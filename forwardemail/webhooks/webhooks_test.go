@@ -0,0 +1,169 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+const signingKey = "4e4d6c332b6fe62a63afe56171fd3725"
+
+func sign(body string) string {
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(body))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestParseWebhook(t *testing.T) {
+	bounce := `{
+		"email": "tony@stark.com",
+		"recipient": "james@rhodes.com",
+		"message": "Mailbox full",
+		"response_code": 552,
+		"bounce": {"action": "reject", "message": "Mailbox full", "category": "capacity", "code": 552, "status": "5.2.2", "line": 42},
+		"message_id": "<a1b2c3@stark.com>",
+		"bounced_at": "2023-10-10T20:12:46.588Z"
+	}`
+	message := `{
+		"subject": "Suit up",
+		"from": {"value": [{"address": "tony@stark.com", "name": "Tony Stark"}], "text": "Tony Stark <tony@stark.com>"},
+		"messageId": "<a1b2c3@stark.com>",
+		"text": "Meet me at the tower.",
+		"recipients": ["james@rhodes.com"]
+	}`
+
+	tests := []struct {
+		name      string
+		body      string
+		signature string
+		maxBytes  int64
+		want      *Event
+		wantErr   error
+	}{
+		{
+			name:      "bounce",
+			body:      bounce,
+			signature: sign(bounce),
+			want: &Event{
+				Bounce: &BounceEvent{
+					Email:        "tony@stark.com",
+					Recipient:    "james@rhodes.com",
+					Message:      "Mailbox full",
+					ResponseCode: 552,
+					Bounce: Bounce{
+						Action:   "reject",
+						Message:  "Mailbox full",
+						Category: "capacity",
+						Code:     552,
+						Status:   "5.2.2",
+						Line:     42,
+					},
+					MessageId: "<a1b2c3@stark.com>",
+					BouncedAt: time.Date(2023, 10, 10, 20, 12, 46, 588000000, time.UTC),
+				},
+			},
+		},
+		{
+			name:      "message",
+			body:      message,
+			signature: sign(message),
+			want: &Event{
+				Message: &MessageEvent{
+					Subject: "Suit up",
+					From: AddressList{
+						Value: []Address{{Address: "tony@stark.com", Name: "Tony Stark"}},
+						Text:  "Tony Stark <tony@stark.com>",
+					},
+					MessageId:  "<a1b2c3@stark.com>",
+					Text:       "Meet me at the tower.",
+					Recipients: []string{"james@rhodes.com"},
+				},
+			},
+		},
+		{
+			name:    "missing signature",
+			body:    bounce,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:      "tampered body",
+			body:      strings.Replace(bounce, "capacity", "spam", 1),
+			signature: sign(bounce),
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "body too large",
+			body:      bounce,
+			signature: sign(bounce),
+			maxBytes:  int64(len(bounce) - 1),
+			wantErr:   ErrBodyTooLarge,
+		},
+		{
+			name:      "body at the limit",
+			body:      message,
+			signature: sign(message),
+			maxBytes:  int64(len(message)),
+			want: &Event{
+				Message: &MessageEvent{
+					Subject: "Suit up",
+					From: AddressList{
+						Value: []Address{{Address: "tony@stark.com", Name: "Tony Stark"}},
+						Text:  "Tony Stark <tony@stark.com>",
+					},
+					MessageId:  "<a1b2c3@stark.com>",
+					Text:       "Meet me at the tower.",
+					Recipients: []string{"james@rhodes.com"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/webhooks/forwardemail", strings.NewReader(tt.body))
+			if tt.signature != "" {
+				r.Header.Set(SignatureHeader, tt.signature)
+			}
+
+			maxBytes := tt.maxBytes
+			if maxBytes == 0 {
+				maxBytes = DefaultMaxBodySize
+			}
+
+			got, err := ParseWebhookLimit(r, signingKey, maxBytes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(Event{}, "Payload")); diff != "" {
+				t.Fatalf("values are not the same %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseWebhook_NoSigningKey(t *testing.T) {
+	body := `{"subject": "Suit up"}`
+	mac := hmac.New(sha256.New, nil)
+	mac.Write([]byte(body))
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	if VerifySignature([]byte(body), signature, "") {
+		t.Error("a body signed with the empty key was verified")
+	}
+
+	r := httptest.NewRequest("POST", "/webhooks/forwardemail", strings.NewReader(body))
+	r.Header.Set(SignatureHeader, signature)
+
+	if _, err := ParseWebhook(r, ""); !errors.Is(err, ErrNoSigningKey) {
+		t.Fatalf("expected error %v, got %v", ErrNoSigningKey, err)
+	}
+}
@@ -2,4 +2,9 @@ module github.com/mattwebbio/go-forwardemail
 
 go 1.21
 
-require github.com/google/go-cmp v0.5.9
+require (
+	github.com/google/go-cmp v0.6.0
+	golang.org/x/net v0.35.0
+)
+
+require golang.org/x/text v0.22.0 // indirect